@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"strings"
+)
+
+// accessTokenVersion is the only machine account access token version
+// understood by the SDK.
+const accessTokenVersion = "0"
+
+// accessTokenEncryptionKeyLength is the decoded length of the encryption key.
+const accessTokenEncryptionKeyLength = 16
+
+// accessToken is a parsed machine account access token, formatted as
+// <version>.<service account id>.<client secret>:<base64 encryption key>.
+type accessToken struct {
+	Version          string
+	ServiceAccountId string
+	ClientSecret     string
+	EncryptionKey    []byte
+}
+
+// parseAccessToken validates the format of an access token locally, without
+// any call to the API.
+func parseAccessToken(token string) (*accessToken, error) {
+	if token == "" {
+		return nil, errors.New("the access token is empty")
+	}
+
+	credentials, encryptionKey, found := strings.Cut(token, ":")
+	if !found {
+		return nil, errors.New("the access token is missing its encryption key, expected <version>.<service account id>.<client secret>:<encryption key>")
+	}
+
+	parts := strings.Split(credentials, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("the access token has %d dot separated parts before the encryption key, expected <version>.<service account id>.<client secret>", len(parts))
+	}
+
+	// The parts are never quoted in errors: a token pasted in the wrong order
+	// would leak its client secret into the diagnostics and CI logs.
+	if parts[0] != accessTokenVersion {
+		return nil, fmt.Errorf("the access token version (%d characters) is not supported, expected version %s", len(parts[0]), accessTokenVersion)
+	}
+
+	if _, err := uuid.ParseUUID(parts[1]); err != nil {
+		return nil, fmt.Errorf("the access token service account id (%d characters) is not a valid UUID", len(parts[1]))
+	}
+
+	if parts[2] == "" {
+		return nil, errors.New("the access token client secret is empty")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encryptionKey)
+	if err != nil {
+		return nil, errors.New("the access token encryption key is not valid base64")
+	}
+	if len(key) != accessTokenEncryptionKeyLength {
+		return nil, fmt.Errorf("the access token encryption key is %d bytes long, expected %d", len(key), accessTokenEncryptionKeyLength)
+	}
+
+	return &accessToken{
+		Version:          parts[0],
+		ServiceAccountId: parts[1],
+		ClientSecret:     parts[2],
+		EncryptionKey:    key,
+	}, nil
+}
+
+// accessTokenValidator checks the format of an access token at plan time.
+type accessTokenValidator struct{}
+
+var _ validator.String = accessTokenValidator{}
+
+func (v accessTokenValidator) Description(_ context.Context) string {
+	return "value must be a machine account access token formatted as <version>.<service account id>.<client secret>:<encryption key>"
+}
+
+func (v accessTokenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v accessTokenValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseAccessToken(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Bitwarden access token",
+			accessTokenErrorDetail(err),
+		)
+	}
+}
+
+// accessTokenErrorDetail explains how to fix an access token that failed to parse.
+func accessTokenErrorDetail(err error) string {
+	return "The provider cannot create the Bitwarden client as the access token is malformed: " + err.Error() + ". " +
+		"Copy the complete access token of the machine account from the Bitwarden web vault, it is only displayed once when created."
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testAccessToken = "0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0:AAECAwQFBgcICQoLDA0ODw=="
+
+func TestParseAccessToken(t *testing.T) {
+	token, err := parseAccessToken(testAccessToken)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if token.Version != "0" || token.ServiceAccountId != "a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60" || token.ClientSecret != "c2VjcmV0" || len(token.EncryptionKey) != 16 {
+		t.Fatalf("unexpected token: %+v", token)
+	}
+
+	testCases := map[string]string{
+		"": "empty",
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0":                          "missing its encryption key",
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60:AAECAwQFBgcICQoLDA0ODw==":          "parts",
+		"1.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0:AAECAwQFBgcICQoLDA0ODw==": "version",
+		"0.not-a-uuid.c2VjcmV0:AAECAwQFBgcICQoLDA0ODw==":                           "UUID",
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.:AAECAwQFBgcICQoLDA0ODw==":         "client secret",
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0:not base64!":              "base64",
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0:AAECAw==":                 "bytes long",
+	}
+	for token, expected := range testCases {
+		_, err := parseAccessToken(token)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected an error containing %q, got %v", token, expected, err)
+		}
+	}
+
+	// Parts pasted in the wrong order must not end up in the error.
+	for _, token := range []string{
+		"c2VjcmV0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.0:AAECAwQFBgcICQoLDA0ODw==",
+		"0.c2VjcmV0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60:AAECAwQFBgcICQoLDA0ODw==",
+	} {
+		_, err := parseAccessToken(token)
+		if err == nil || strings.Contains(err.Error(), "c2VjcmV0") {
+			t.Errorf("expected an error without the client secret, got %v", err)
+		}
+	}
+}
+
+func TestAccessTokenInfoFunction(t *testing.T) {
+	response := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(accessTokenInfoAttributeTypes))}
+	NewAccessTokenInfoFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testAccessToken)}),
+	}, &response)
+	if response.Error != nil {
+		t.Fatalf("err: %s", response.Error)
+	}
+
+	attributes := response.Result.Value().(types.Object).Attributes()
+	if len(attributes) != 2 || attributes["service_account_id"].(types.String).ValueString() != "a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60" {
+		t.Fatalf("unexpected result: %v", attributes)
+	}
+}
+
+func TestAccessTokenValidator(t *testing.T) {
+	for token, valid := range map[string]bool{
+		testAccessToken: true,
+		"0.a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60.c2VjcmV0": false,
+	} {
+		response := &validator.StringResponse{}
+		accessTokenValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("access_token"),
+			ConfigValue: types.StringValue(token),
+		}, response)
+
+		if response.Diagnostics.HasError() == valid {
+			t.Errorf("%q: unexpected diagnostics: %v", token, response.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"regexp"
+	"strings"
+)
+
+// apiErrorKind is the cause of a failed API call, as far as it can be told
+// from the SDK error message.
+type apiErrorKind int
+
+const (
+	apiErrorUnknown apiErrorKind = iota
+	apiErrorUnauthorized
+	apiErrorForbidden
+	apiErrorNotFound
+	apiErrorValidation
+	apiErrorRateLimited
+	apiErrorNetwork
+	apiErrorTimeout
+)
+
+// apiStatusPattern matches the HTTP status of the errors returned by the
+// server, such as "Received error message from server: [404 Not Found] {...}".
+var apiStatusPattern = regexp.MustCompile(`received error message from server: \[(\d{3}) `)
+
+// apiStatusKinds are the kinds of the HTTP statuses returned by the server.
+var apiStatusKinds = map[string]apiErrorKind{
+	"400": apiErrorValidation,
+	"401": apiErrorUnauthorized,
+	"403": apiErrorForbidden,
+	"404": apiErrorNotFound,
+	"422": apiErrorValidation,
+	"429": apiErrorRateLimited,
+	"500": apiErrorNetwork,
+	"502": apiErrorNetwork,
+	"503": apiErrorNetwork,
+	"504": apiErrorNetwork,
+}
+
+// The markers identify the errors without a server status. Status codes are
+// only matched with their reason phrase, so identifiers containing the digits
+// don't match.
+var (
+	unauthorizedMarkers = []string{"401 unauthorized", "not authenticated", "session has expired", "invalid_client", "invalid_grant", "access token is not in a valid format"}
+	forbiddenMarkers    = []string{"403 forbidden"}
+	notFoundMarkers     = []string{"404 not found", "not found"}
+	validationMarkers   = []string{"400 bad request", "422 unprocessable entity"}
+	networkMarkers      = []string{"dns error", "no such host", "certificate", "tls", "network"}
+)
+
+// apiErrorHints are the summary and remediation hint of every kind of error.
+var apiErrorHints = map[apiErrorKind]struct {
+	summary string
+	hint    string
+}{
+	apiErrorUnauthorized: {
+		"authentication failed",
+		"Bitwarden rejected the access token. Check that the access token is valid and not revoked, and that api_url and identity_url point at the server which issued it.",
+	},
+	apiErrorForbidden: {
+		"access denied",
+		"The machine account is not allowed to do this. Grant it access to the project, with write access for changes, in the Bitwarden web vault.",
+	},
+	apiErrorNotFound: {
+		"not found",
+		"The object doesn't exist, was deleted outside of Terraform, or the machine account can't see it. Check the ids and the project access of the machine account.",
+	},
+	apiErrorValidation: {
+		"request rejected",
+		"Bitwarden refused the values sent. Check the configured keys, values, names and ids.",
+	},
+	apiErrorRateLimited: {
+		"rate limited",
+		"Bitwarden kept throttling the calls after every retry. Lower requests_per_second, raise max_retries or reduce the Terraform parallelism.",
+	},
+	apiErrorNetwork: {
+		"Bitwarden unavailable",
+		"The Bitwarden API could not be reached or kept failing after every retry. Check the network connection, the proxy settings, api_url and identity_url.",
+	},
+	apiErrorTimeout: {
+		"timed out",
+		"The operation did not complete within its timeout. Increase it in the timeouts block if the API is slow.",
+	},
+	apiErrorUnknown: {
+		"unexpected error",
+		"Bitwarden returned an unexpected error.",
+	},
+}
+
+// classifyAPIError tells the cause of a failed API call, from the server
+// status when there is one.
+func classifyAPIError(err error) apiErrorKind {
+	var timeoutErr *callTimeoutError
+	if errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded) {
+		return apiErrorTimeout
+	}
+
+	message := strings.ToLower(err.Error())
+	if match := apiStatusPattern.FindStringSubmatch(message); match != nil {
+		if kind, ok := apiStatusKinds[match[1]]; ok {
+			return kind
+		}
+	}
+
+	switch {
+	case containsAny(message, rateLimitedMarkers):
+		return apiErrorRateLimited
+	case containsAny(message, unauthorizedMarkers):
+		return apiErrorUnauthorized
+	case containsAny(message, forbiddenMarkers):
+		return apiErrorForbidden
+	case containsAny(message, notFoundMarkers):
+		return apiErrorNotFound
+	case containsAny(message, transientMarkers), containsAny(message, networkMarkers):
+		return apiErrorNetwork
+	case containsAny(message, validationMarkers):
+		return apiErrorValidation
+	}
+	return apiErrorUnknown
+}
+
+// apiErrorSummaryDetail describes a failed API call: action says what failed,
+// such as "read secret", and the kind of error adds a remediation hint.
+func apiErrorSummaryDetail(action string, err error) (string, string) {
+	hint := apiErrorHints[classifyAPIError(err)]
+	return "Unable to " + action + ": " + hint.summary, hint.hint + "\n\nBitwarden error: " + err.Error()
+}
+
+// addAPIError reports a failed API call.
+func addAPIError(diags *diag.Diagnostics, action string, err error) {
+	summary, detail := apiErrorSummaryDetail(action, err)
+	diags.AddError(summary, detail)
+}
+
+// addAttributeAPIError reports a failed API call caused by an attribute.
+func addAttributeAPIError(diags *diag.Diagnostics, attributePath path.Path, action string, err error) {
+	summary, detail := apiErrorSummaryDetail(action, err)
+	diags.AddAttributeError(attributePath, summary, detail)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		kind apiErrorKind
+	}{
+		"unauthorized": {
+			err:  errors.New("API error: Received error message from server: [401 Unauthorized] {\"error\":\"invalid_client\"}"),
+			kind: apiErrorUnauthorized,
+		},
+		"expired session": {
+			err:  errors.New("The client is not authenticated or the session has expired"),
+			kind: apiErrorUnauthorized,
+		},
+		"forbidden": {
+			err:  errors.New("API error: Received error message from server: [403 Forbidden]"),
+			kind: apiErrorForbidden,
+		},
+		"not found": {
+			err:  fakeNotFound("secret", "0d7e9c3a-52b1-4f6e-a8d4-7c1b2e3f4a5b"),
+			kind: apiErrorNotFound,
+		},
+		"validation": {
+			err:  errors.New("API error: Received error message from server: [400 Bad Request] {\"message\":\"The model state is invalid.\"}"),
+			kind: apiErrorValidation,
+		},
+		"rate limited": {
+			err:  errors.New("API error: Received error message from server: [429 Too Many Requests]"),
+			kind: apiErrorRateLimited,
+		},
+		"network": {
+			err:  errors.New("error sending request for url (https://api.bitwarden.com/): dns error: no such host"),
+			kind: apiErrorNetwork,
+		},
+		"server": {
+			err:  errors.New("API error: Received error message from server: [503 Service Unavailable]"),
+			kind: apiErrorNetwork,
+		},
+		"timeout": {
+			err:  &callTimeoutError{operation: "secrets.get", err: context.DeadlineExceeded},
+			kind: apiErrorTimeout,
+		},
+		"unknown": {
+			err:  errors.New("Internal error: something broke"),
+			kind: apiErrorUnknown,
+		},
+		"invalid value": {
+			err:  errors.New("Internal error: invalid secret value"),
+			kind: apiErrorUnknown,
+		},
+		"not found mentioning the access token": {
+			err:  errors.New("API error: Received error message from server: [404 Not Found] {\"message\":\"Resource not found, check the access token permissions.\"}"),
+			kind: apiErrorNotFound,
+		},
+		"status code in identifier": {
+			err:  fakeNotFound("secret", "4013f7a2-403b-4e6e-a400-7c1b2e3f4a5b"),
+			kind: apiErrorNotFound,
+		},
+		"bad request mentioning not found": {
+			err:  errors.New("API error: Received error message from server: [400 Bad Request] {\"message\":\"Project not found.\"}"),
+			kind: apiErrorValidation,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if kind := classifyAPIError(testCase.err); kind != testCase.kind {
+				t.Fatalf("expected kind %d, got %d", testCase.kind, kind)
+			}
+		})
+	}
+}
+
+func TestAPIErrorSummaryDetail(t *testing.T) {
+	err := errors.New("API error: Received error message from server: [403 Forbidden]")
+	summary, detail := apiErrorSummaryDetail("update secret", err)
+
+	if summary != "Unable to update secret: access denied" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if !strings.Contains(detail, "Grant it access to the project") || !strings.HasSuffix(detail, err.Error()) {
+		t.Errorf("expected a hint and the original error, got %q", detail)
+	}
+}
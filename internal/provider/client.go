@@ -0,0 +1,408 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// rateLimitedMarkers identify errors returned when the API throttles the
+// client. Status codes are only matched with their reason phrase, so
+// identifiers containing the digits don't match.
+var rateLimitedMarkers = []string{
+	"too many requests",
+	"rate limit exceeded",
+}
+
+// rejectedMarkers identify errors returned for requests which were rejected
+// before being processed: the API answered with a 429 status or the
+// connection was refused. They are always safe to retry.
+var rejectedMarkers = []string{
+	"429 too many requests",
+	"connection refused",
+}
+
+// transientMarkers identify server and network errors which are worth
+// retrying for idempotent calls.
+var transientMarkers = []string{
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"error sending request",
+}
+
+// clientOptions configures the behaviour of apiClient.
+type clientOptions struct {
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	// requestsPerSecond limits the rate of API calls, 0 meaning no limit.
+	requestsPerSecond float64
+	// readCacheTTL is how long read responses are reused, 0 disabling the
+	// read cache.
+	readCacheTTL time.Duration
+}
+
+// apiClient wraps the SDK clients shared by every resource and data source.
+// Calls are made through the context bound views returned by Projects and
+// Secrets, so they can be retried and logged.
+//
+// The SDK wraps a native library whose thread safety isn't documented, so
+// each SDK client is only ever used by one call at a time: calls borrow a
+// client from the pool and wait when every client is busy.
+type apiClient struct {
+	// clients are all the SDK clients of the pool.
+	clients []bitwarden.BitwardenClientInterface
+	// idle holds the clients which are not used by a call.
+	idle    chan bitwarden.BitwardenClientInterface
+	options clientOptions
+	// limiter is a token bucket shared by every call, nil when unlimited.
+	limiter *rate.Limiter
+	// cache holds the responses of read calls, nil when disabled.
+	cache *readCache
+	// syncs holds the secrets synced per organization id.
+	syncsMu sync.Mutex
+	syncs   map[string]*secretSnapshot
+	// batcher merges concurrent secret reads.
+	batcher *secretBatcher
+}
+
+func newAPIClient(clients []bitwarden.BitwardenClientInterface, options clientOptions) *apiClient {
+	c := &apiClient{
+		clients: clients,
+		idle:    make(chan bitwarden.BitwardenClientInterface, len(clients)),
+		options: options,
+		syncs:   map[string]*secretSnapshot{},
+	}
+	for _, client := range clients {
+		c.idle <- client
+	}
+
+	if options.requestsPerSecond > 0 {
+		burst := int(math.Ceil(options.requestsPerSecond))
+		c.limiter = rate.NewLimiter(rate.Limit(options.requestsPerSecond), burst)
+	}
+
+	if options.readCacheTTL > 0 {
+		c.cache = newReadCache(options.readCacheTTL)
+	}
+
+	c.batcher = newSecretBatcher(c, secretBatchWindow, secretBatchSize)
+
+	return c
+}
+
+// AccessTokenLogin authenticates every client of the pool with a machine
+// account access token. It must be called before the client is shared.
+func (c *apiClient) AccessTokenLogin(ctx context.Context, accessToken string) error {
+	ctx = newLogSubsystem(ctx, logSubsystemClient)
+
+	for _, client := range c.clients {
+		err := c.retry(ctx, "access_token_login", true, func() error {
+			return client.AccessTokenLogin(accessToken, nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Projects returns the project operations, bound to ctx.
+func (c *apiClient) Projects(ctx context.Context) bitwarden.ProjectsInterface {
+	return &projectsClient{client: c, ctx: newLogSubsystem(ctx, logSubsystemClient)}
+}
+
+// Secrets returns the secret operations, bound to ctx.
+func (c *apiClient) Secrets(ctx context.Context) bitwarden.SecretsInterface {
+	return &secretsClient{client: c, ctx: newLogSubsystem(ctx, logSubsystemClient)}
+}
+
+// UncachedSecret reads a secret without the read cache, for values which are
+// written back and must not be stale.
+func (c *apiClient) UncachedSecret(ctx context.Context, secretID string) (*bitwarden.SecretResponse, error) {
+	return callResult(newLogSubsystem(ctx, logSubsystemClient), c, "secrets.get", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretResponse, error) {
+		return client.Secrets().Get(secretID)
+	})
+}
+
+// acquire borrows an idle client from the pool, waiting for one if needed.
+func (c *apiClient) acquire(ctx context.Context) (bitwarden.BitwardenClientInterface, error) {
+	select {
+	case client := <-c.idle:
+		return client, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release gives a borrowed client back to the pool.
+func (c *apiClient) release(client bitwarden.BitwardenClientInterface) {
+	c.idle <- client
+}
+
+// retry runs fn, retrying it with an exponential backoff while it fails with
+// a retryable error. Calls which are not idempotent are only retried when the
+// API rejected them before processing. Every attempt waits for the rate
+// limiter first.
+func (c *apiClient) retry(ctx context.Context, operation string, idempotent bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx, operation); err != nil {
+			return err
+		}
+
+		err := fn()
+		if err == nil || attempt >= c.options.maxRetries || !isRetryableError(err, idempotent) {
+			return err
+		}
+
+		backoff := c.backoff(attempt)
+		tflog.SubsystemWarn(ctx, logSubsystemClient, "Retrying Bitwarden API call", map[string]any{
+			"operation": operation,
+			"attempt":   attempt + 1,
+			"backoff":   backoff.String(),
+			"error":     err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// wait blocks until the rate limiter allows one more call.
+func (c *apiClient) wait(ctx context.Context, operation string) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	reservation := c.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystemClient, "Waiting for the Bitwarden API rate limit", map[string]any{
+		"operation": operation,
+		"delay":     delay.String(),
+	})
+
+	timer := time.NewTimer(delay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff returns the delay before the given retry attempt: the minimum
+// backoff doubled on every attempt, capped to the maximum backoff, with
+// jitter so parallel calls don't retry in lockstep.
+func (c *apiClient) backoff(attempt int) time.Duration {
+	backoff := c.options.maxBackoff
+	if attempt < 32 {
+		if exponential := c.options.minBackoff << attempt; exponential > 0 && exponential < backoff {
+			backoff = exponential
+		}
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// isRetryableError reports whether a failed call is worth retrying.
+func isRetryableError(err error, idempotent bool) bool {
+	message := strings.ToLower(err.Error())
+	if containsAny(message, rejectedMarkers) {
+		return true
+	}
+	return idempotent && (containsAny(message, rateLimitedMarkers) || containsAny(message, transientMarkers))
+}
+
+func containsAny(message string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// callResponse is the outcome of an SDK call.
+type callResponse[T any] struct {
+	value T
+	err   error
+}
+
+// callResult runs an SDK call on a client borrowed from the pool, which is
+// given back between retries. The SDK calls don't take a context, so they
+// run in their own goroutine and are abandoned when ctx is done; the client
+// only returns to the pool once the abandoned call returned.
+func callResult[T any](ctx context.Context, c *apiClient, operation string, idempotent bool, fn func(client bitwarden.BitwardenClientInterface) (T, error)) (T, error) {
+	start := time.Now()
+	var result T
+	err := c.retry(ctx, operation, idempotent, func() error {
+		client, err := c.acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		responses := make(chan callResponse[T], 1)
+		go func() {
+			defer c.release(client)
+
+			value, err := fn(client)
+			responses <- callResponse[T]{value: value, err: err}
+		}()
+
+		select {
+		case response := <-responses:
+			result = response.value
+			return response.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = &callTimeoutError{operation: operation, err: err}
+	}
+
+	fields := map[string]any{
+		"operation":   operation,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.SubsystemDebug(ctx, logSubsystemClient, "Called the Bitwarden API", fields)
+
+	return result, err
+}
+
+// callTimeoutError is returned by calls aborted by the operation timeout.
+type callTimeoutError struct {
+	operation string
+	err       error
+}
+
+func (e *callTimeoutError) Error() string {
+	return fmt.Sprintf("the Bitwarden API did not answer the %s call before the operation timed out, a write may still be applied. Increase the timeout in the timeouts block if the API is slow", e.operation)
+}
+
+func (e *callTimeoutError) Unwrap() error {
+	return e.err
+}
+
+// projectsClient implements bitwarden.ProjectsInterface on top of apiClient.
+type projectsClient struct {
+	client *apiClient
+	ctx    context.Context
+}
+
+func (p *projectsClient) Create(organizationID string, name string) (*bitwarden.ProjectResponse, error) {
+	return writeResult(p.ctx, p.client, "projects.create", false, func(client bitwarden.BitwardenClientInterface) (*bitwarden.ProjectResponse, error) {
+		return client.Projects().Create(organizationID, name)
+	})
+}
+
+func (p *projectsClient) List(organizationID string) (*bitwarden.ProjectsResponse, error) {
+	return cachedResult(p.ctx, p.client, "projects.list", []string{organizationID}, func(client bitwarden.BitwardenClientInterface) (*bitwarden.ProjectsResponse, error) {
+		return client.Projects().List(organizationID)
+	})
+}
+
+func (p *projectsClient) Get(projectID string) (*bitwarden.ProjectResponse, error) {
+	return cachedResult(p.ctx, p.client, "projects.get", []string{projectID}, func(client bitwarden.BitwardenClientInterface) (*bitwarden.ProjectResponse, error) {
+		return client.Projects().Get(projectID)
+	})
+}
+
+func (p *projectsClient) Update(projectID string, organizationID string, name string) (*bitwarden.ProjectResponse, error) {
+	return writeResult(p.ctx, p.client, "projects.update", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.ProjectResponse, error) {
+		return client.Projects().Update(projectID, organizationID, name)
+	})
+}
+
+func (p *projectsClient) Delete(projectIDs []string) (*bitwarden.ProjectsDeleteResponse, error) {
+	return writeResult(p.ctx, p.client, "projects.delete", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.ProjectsDeleteResponse, error) {
+		return client.Projects().Delete(projectIDs)
+	})
+}
+
+// secretsClient implements bitwarden.SecretsInterface on top of apiClient.
+type secretsClient struct {
+	client *apiClient
+	ctx    context.Context
+}
+
+func (s *secretsClient) Create(key, value, note string, organizationID string, projectIDs []string) (*bitwarden.SecretResponse, error) {
+	return writeResult(s.ctx, s.client, "secrets.create", false, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretResponse, error) {
+		return client.Secrets().Create(key, value, note, organizationID, projectIDs)
+	})
+}
+
+func (s *secretsClient) List(organizationID string) (*bitwarden.SecretIdentifiersResponse, error) {
+	return cachedResult(s.ctx, s.client, "secrets.list", []string{organizationID}, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretIdentifiersResponse, error) {
+		return client.Secrets().List(organizationID)
+	})
+}
+
+func (s *secretsClient) Get(secretID string) (*bitwarden.SecretResponse, error) {
+	return cachedResult(s.ctx, s.client, "secrets.get", []string{secretID}, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretResponse, error) {
+		return client.Secrets().Get(secretID)
+	})
+}
+
+func (s *secretsClient) GetByIDS(secretIDs []string) (*bitwarden.SecretsResponse, error) {
+	return cachedResult(s.ctx, s.client, "secrets.get_by_ids", secretIDs, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretsResponse, error) {
+		return client.Secrets().GetByIDS(secretIDs)
+	})
+}
+
+func (s *secretsClient) Update(secretID string, key, value, note string, organizationID string, projectIDs []string) (*bitwarden.SecretResponse, error) {
+	return writeResult(s.ctx, s.client, "secrets.update", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretResponse, error) {
+		return client.Secrets().Update(secretID, key, value, note, organizationID, projectIDs)
+	})
+}
+
+func (s *secretsClient) Delete(secretIDs []string) (*bitwarden.SecretsDeleteResponse, error) {
+	return writeResult(s.ctx, s.client, "secrets.delete", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretsDeleteResponse, error) {
+		return client.Secrets().Delete(secretIDs)
+	})
+}
+
+func (s *secretsClient) Sync(organizationID string, lastSyncedDate *time.Time) (*bitwarden.SecretsSyncResponse, error) {
+	return callResult(s.ctx, s.client, "secrets.sync", true, func(client bitwarden.BitwardenClientInterface) (*bitwarden.SecretsSyncResponse, error) {
+		return client.Secrets().Sync(organizationID, lastSyncedDate)
+	})
+}
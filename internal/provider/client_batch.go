@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sync"
+	"time"
+)
+
+const (
+	// secretBatchWindow is how long the first read of a batch waits for other
+	// reads to join it.
+	secretBatchWindow = 20 * time.Millisecond
+	// secretBatchSize is the maximum number of secrets read by one call.
+	secretBatchSize = 100
+)
+
+// secretBatcher merges the secret reads made at about the same time, by
+// concurrent refreshes for instance, into GetByIDS calls.
+type secretBatcher struct {
+	client  *apiClient
+	window  time.Duration
+	maxSize int
+
+	mu sync.Mutex
+	// ctx is the context of the read which opened the pending batch.
+	ctx     context.Context
+	pending map[string][]chan secretBatchResult
+	ids     []string
+	timer   *time.Timer
+}
+
+type secretBatchResult struct {
+	secret *bitwarden.SecretResponse
+	err    error
+}
+
+func newSecretBatcher(client *apiClient, window time.Duration, maxSize int) *secretBatcher {
+	return &secretBatcher{
+		client:  client,
+		window:  window,
+		maxSize: maxSize,
+		pending: map[string][]chan secretBatchResult{},
+	}
+}
+
+// BatchedSecrets reads secrets by id, in the order of the ids, sharing the
+// GetByIDS calls with the other reads made within the batching window.
+func (c *apiClient) BatchedSecrets(ctx context.Context, secretIds []string) ([]*bitwarden.SecretResponse, error) {
+	return c.batcher.get(newLogSubsystem(ctx, logSubsystemClient), secretIds)
+}
+
+func (b *secretBatcher) get(ctx context.Context, secretIds []string) ([]*bitwarden.SecretResponse, error) {
+	results := make([]chan secretBatchResult, len(secretIds))
+
+	b.mu.Lock()
+	for i, secretId := range secretIds {
+		results[i] = make(chan secretBatchResult, 1)
+		if len(b.ids) == 0 {
+			b.ctx = ctx
+			b.timer = time.AfterFunc(b.window, b.flush)
+		}
+		if _, ok := b.pending[secretId]; !ok {
+			b.ids = append(b.ids, secretId)
+		}
+		b.pending[secretId] = append(b.pending[secretId], results[i])
+		if len(b.ids) >= b.maxSize {
+			b.timer.Stop()
+			go b.send(b.take())
+		}
+	}
+	b.mu.Unlock()
+
+	secrets := make([]*bitwarden.SecretResponse, len(secretIds))
+	for i, result := range results {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &callTimeoutError{operation: "secrets.get_by_ids", err: ctx.Err()}
+			}
+			return nil, ctx.Err()
+		case result := <-result:
+			if result.err != nil {
+				return nil, result.err
+			}
+			secrets[i] = result.secret
+		}
+	}
+	return secrets, nil
+}
+
+// flush sends the pending batch once the window elapsed.
+func (b *secretBatcher) flush() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+
+	b.send(batch)
+}
+
+// secretBatch is a batch of reads taken out of the batcher.
+type secretBatch struct {
+	ctx     context.Context
+	ids     []string
+	waiters map[string][]chan secretBatchResult
+}
+
+// take removes the pending batch from the batcher. The caller must hold b.mu.
+func (b *secretBatcher) take() secretBatch {
+	batch := secretBatch{ctx: b.ctx, ids: b.ids, waiters: b.pending}
+	b.ctx = nil
+	b.ids = nil
+	b.pending = map[string][]chan secretBatchResult{}
+	return batch
+}
+
+// send reads a batch and hands every waiter its secret. GetByIDS fails as a
+// whole when any secret can't be read, so the secrets are then read one by
+// one, spread over the pool, to give every waiter its own result.
+func (b *secretBatcher) send(batch secretBatch) {
+	if len(batch.ids) == 0 {
+		return
+	}
+
+	// The batch is shared, it must not fail because the read which opened it
+	// was cancelled. It is still bounded, as no caller's timeout covers it.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(batch.ctx), defaultOperationTimeout)
+	defer cancel()
+
+	secrets := make(map[string]*bitwarden.SecretResponse, len(batch.ids))
+	response, err := b.client.Secrets(ctx).GetByIDS(batch.ids)
+	if err == nil {
+		for i := range response.Data {
+			secrets[response.Data[i].ID] = &response.Data[i]
+		}
+	} else {
+		tflog.SubsystemDebug(ctx, logSubsystemClient, "Unable to read the batch of secrets, reading them one by one", map[string]any{
+			"secrets": len(batch.ids),
+			"error":   err.Error(),
+		})
+	}
+
+	var missing []string
+	for _, secretId := range batch.ids {
+		if secret, ok := secrets[secretId]; ok {
+			batch.deliver(secretId, secretBatchResult{secret: secret})
+		} else {
+			missing = append(missing, secretId)
+		}
+	}
+
+	secretIds := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(len(b.client.clients), len(missing)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for secretId := range secretIds {
+				secret, err := b.client.Secrets(ctx).Get(secretId)
+				batch.deliver(secretId, secretBatchResult{secret: secret, err: err})
+			}
+		}()
+	}
+	for _, secretId := range missing {
+		secretIds <- secretId
+	}
+	close(secretIds)
+	wg.Wait()
+}
+
+// deliver hands a result to every waiter of the secret.
+func (batch secretBatch) deliver(secretId string, result secretBatchResult) {
+	for _, waiter := range batch.waiters[secretId] {
+		waiter <- result
+	}
+}
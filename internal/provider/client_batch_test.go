@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	bitwarden "github.com/bitwarden/sdk-go"
+)
+
+func TestSecretBatcherMergesReads(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	var secretIds []string
+	for i := 0; i < 250; i++ {
+		secretIds = append(secretIds, fake.addSecret(testOrganizationId, project.ID, "KEY", "value").ID)
+	}
+	client := newTestAPIClient(fake)
+	client.batcher = newSecretBatcher(client, 200*time.Millisecond, 100)
+	setupCalls := fake.callCount()
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(secretIds); i += 2 {
+		wg.Add(1)
+		go func(ids []string) {
+			defer wg.Done()
+			secrets, err := client.BatchedSecrets(context.Background(), ids)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			for i, secret := range secrets {
+				if secret.ID != ids[i] {
+					t.Errorf("expected secret %s, got %s", ids[i], secret.ID)
+				}
+			}
+		}(secretIds[i : i+2])
+	}
+	wg.Wait()
+
+	if calls := fake.callCount() - setupCalls; calls != 3 {
+		t.Fatalf("expected 250 secrets to be read in 3 calls, got %d", calls)
+	}
+}
+
+func TestSecretBatcherFallback(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	secret := fake.addSecret(testOrganizationId, project.ID, "KEY", "value")
+	client := newTestAPIClient(fake)
+	missing := fakeId()
+
+	var wg sync.WaitGroup
+	var found, notFound error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, found = client.BatchedSecrets(context.Background(), []string{secret.ID})
+	}()
+	go func() {
+		defer wg.Done()
+		_, notFound = client.BatchedSecrets(context.Background(), []string{missing})
+	}()
+	wg.Wait()
+
+	if found != nil {
+		t.Fatalf("expected the existing secret to be read, got %s", found)
+	}
+	if notFound == nil {
+		t.Fatal("expected the missing secret to fail")
+	}
+}
+
+func TestSecretBatcherFallbackResults(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	var secretIds []string
+	for i := 0; i < 5; i++ {
+		secretIds = append(secretIds, fake.addSecret(testOrganizationId, project.ID, fmt.Sprintf("KEY_%d", i), "value").ID)
+	}
+	// The missing secret fails the GetByIDS call of the whole batch.
+	missingIndex := 2
+	secretIds[missingIndex] = fakeId()
+	client := newAPIClient([]bitwarden.BitwardenClientInterface{fake, fake}, clientOptions{})
+	client.batcher = newSecretBatcher(client, 50*time.Millisecond, 100)
+
+	errs := make([]error, len(secretIds))
+	var wg sync.WaitGroup
+	for i, secretId := range secretIds {
+		wg.Add(1)
+		go func(i int, secretId string) {
+			defer wg.Done()
+			secrets, err := client.BatchedSecrets(context.Background(), []string{secretId})
+			if err == nil && secrets[0].ID != secretId {
+				err = fmt.Errorf("expected secret %s, got %s", secretId, secrets[0].ID)
+			}
+			errs[i] = err
+		}(i, secretId)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		missing := i == missingIndex
+		if (err != nil) != missing {
+			t.Errorf("unexpected result for secret %s, missing %t: %v", secretIds[i], missing, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultReadCacheTTL is how long a read response is reused. It is short so
+// a run sees the changes made outside of Terraform shortly before it.
+const defaultReadCacheTTL = 30 * time.Second
+
+// readCache shares the responses of read calls made with the same arguments,
+// including calls which are still in flight. Any write empties the cache.
+type readCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*readCacheEntry
+}
+
+// readCacheEntry is the response of one read call. done is closed once the
+// call returned.
+type readCacheEntry struct {
+	done    chan struct{}
+	value   any
+	err     error
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		entries: map[string]*readCacheEntry{},
+	}
+}
+
+// get returns the cached response for key, running fn when there is none.
+// fn runs detached from the caller's cancellation, so a caller giving up
+// doesn't fail the call for the others waiting on it. Failed calls are not
+// cached.
+func (c *readCache) get(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		select {
+		case <-entry.done:
+			if time.Now().After(entry.expires) {
+				ok = false
+			}
+		default:
+		}
+	}
+	if ok {
+		tflog.SubsystemDebug(ctx, logSubsystemClient, "Using cached Bitwarden API response", map[string]any{"key": key})
+	} else {
+		entry = &readCacheEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+		go c.fill(context.WithoutCancel(ctx), key, entry, fn)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.value, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fill runs fn and stores its response in entry.
+func (c *readCache) fill(ctx context.Context, key string, entry *readCacheEntry, fn func(ctx context.Context) (any, error)) {
+	entry.value, entry.err = fn(ctx)
+	entry.expires = time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	if entry.err != nil && c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+}
+
+// invalidate drops every cached response. Calls in flight still answer the
+// callers already waiting for them but are not cached anymore.
+func (c *readCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*readCacheEntry{}
+}
+
+// readCacheKey identifies a read call by its operation and arguments.
+func readCacheKey(operation string, arguments ...string) string {
+	return operation + "(" + strings.Join(arguments, ",") + ")"
+}
+
+// cachedResult runs a read call through the read cache, when it is enabled.
+func cachedResult[T any](ctx context.Context, c *apiClient, operation string, arguments []string, fn func(client bitwarden.BitwardenClientInterface) (T, error)) (T, error) {
+	if c.cache == nil {
+		return callResult(ctx, c, operation, true, fn)
+	}
+
+	value, err := c.cache.get(ctx, readCacheKey(operation, arguments...), func(ctx context.Context) (any, error) {
+		return callResult(ctx, c, operation, true, fn)
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = &callTimeoutError{operation: operation, err: err}
+	}
+	result, _ := value.(T)
+	return result, err
+}
+
+// writeResult runs a write call and empties the read cache and the synced
+// secrets, even when the call failed as it may have been partially applied.
+func writeResult[T any](ctx context.Context, c *apiClient, operation string, idempotent bool, fn func(client bitwarden.BitwardenClientInterface) (T, error)) (T, error) {
+	defer c.invalidate()
+	return callResult(ctx, c, operation, idempotent, fn)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	bitwarden "github.com/bitwarden/sdk-go"
+)
+
+func newTestCachedAPIClient(client bitwarden.BitwardenClientInterface, ttl time.Duration) *apiClient {
+	return newAPIClient([]bitwarden.BitwardenClientInterface{client}, clientOptions{readCacheTTL: ttl})
+}
+
+func TestReadCacheCollapsesReads(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	client := newTestCachedAPIClient(fake, time.Minute)
+	ctx := context.Background()
+	setupCalls := fake.callCount()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := client.Projects(ctx).Get(project.ID)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			if got.Name != "project" {
+				t.Errorf("expected the project name, got %q", got.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls := fake.callCount() - setupCalls; calls != 2 {
+		t.Fatalf("expected one call per distinct read, got %d", calls)
+	}
+}
+
+func TestReadCacheInvalidatedByWrites(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	client := newTestCachedAPIClient(fake, time.Minute)
+	ctx := context.Background()
+
+	if _, err := client.Projects(ctx).Get(project.ID); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := client.Projects(ctx).Update(project.ID, testOrganizationId, "renamed"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	got, err := client.Projects(ctx).Get(project.ID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got.Name != "renamed" {
+		t.Fatalf("expected the write to invalidate the cache, got %q", got.Name)
+	}
+}
+
+func TestReadCacheExpires(t *testing.T) {
+	fake := newFakeClient()
+	client := newTestCachedAPIClient(fake, 10*time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls := fake.callCount(); calls != 2 {
+		t.Fatalf("expected the expired response to be read again, got %d calls", calls)
+	}
+}
+
+func TestReadCacheSkipsErrors(t *testing.T) {
+	fake := newFakeClient()
+	fake.errors = []error{errors.New("API error: Resource not found.")}
+	client := newTestCachedAPIClient(fake, time.Minute)
+	ctx := context.Background()
+
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err == nil {
+		t.Fatal("expected the injected error")
+	}
+	if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+		t.Fatalf("expected the failed read not to be cached, got %s", err)
+	}
+}
+
+func TestReadCacheCancelledCaller(t *testing.T) {
+	fake := &slowClient{fakeClient: newFakeClient(), release: make(chan struct{}), started: make(chan struct{}, 2)}
+	project := fake.addProject(testOrganizationId, "project")
+	secret := fake.addSecret(testOrganizationId, project.ID, "KEY", "value")
+	client := newTestCachedAPIClient(fake, time.Minute)
+	setupCalls := fake.callCount()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := client.Secrets(ctx).Get(secret.ID)
+		cancelled <- err
+	}()
+	<-fake.started
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the first caller to be cancelled, got %v", err)
+	}
+
+	// The call started by the cancelled caller still answers the next one.
+	results := make(chan error, 1)
+	go func() {
+		got, err := client.Secrets(context.Background()).Get(secret.ID)
+		if err == nil && got.Value != "value" {
+			err = fmt.Errorf("unexpected value %q", got.Value)
+		}
+		results <- err
+	}()
+	close(fake.release)
+	if err := <-results; err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls := fake.callCount() - setupCalls; calls != 1 {
+		t.Fatalf("expected the waiter to share the call, got %d calls", calls)
+	}
+}
+
+func TestReadCacheTimeout(t *testing.T) {
+	fake := &slowClient{fakeClient: newFakeClient(), release: make(chan struct{})}
+	defer close(fake.release)
+	project := fake.addProject(testOrganizationId, "project")
+	secret := fake.addSecret(testOrganizationId, project.ID, "KEY", "value")
+	client := newTestCachedAPIClient(fake, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Secrets(ctx).Get(secret.ID)
+	var timeoutErr *callTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+}
+
+func TestReadCacheDisabled(t *testing.T) {
+	fake := newFakeClient()
+	client := newTestCachedAPIClient(fake, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Secrets(ctx).List(testOrganizationId); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	if calls := fake.callCount(); calls != 2 {
+		t.Fatalf("expected every read to reach the API, got %d calls", calls)
+	}
+}
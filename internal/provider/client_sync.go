@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sync"
+	"time"
+)
+
+// secretSnapshot is every secret of an organization the machine account can
+// read, as returned by the last sync.
+type secretSnapshot struct {
+	mu      sync.Mutex
+	secrets []bitwarden.SecretResponse
+	// synced is when the last sync started, nil before the first one.
+	synced *time.Time
+	// checked is when the snapshot was last confirmed up to date.
+	checked time.Time
+}
+
+// SyncedSecrets returns every secret of an organization in one call. The
+// secrets are kept for the run: later calls only ask the API whether
+// anything changed since the last sync, and reuse the snapshot without any
+// call while the read cache would.
+func (c *apiClient) SyncedSecrets(ctx context.Context, organizationId string) ([]bitwarden.SecretResponse, error) {
+	ctx = newLogSubsystem(ctx, logSubsystemClient)
+
+	c.syncsMu.Lock()
+	snapshot, ok := c.syncs[organizationId]
+	if !ok {
+		snapshot = &secretSnapshot{}
+		c.syncs[organizationId] = snapshot
+	}
+	c.syncsMu.Unlock()
+
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+
+	if snapshot.synced != nil && time.Since(snapshot.checked) < c.options.readCacheTTL {
+		return snapshot.secrets, nil
+	}
+
+	// The SDK truncates the date to the second, so changes made while the
+	// sync runs are picked up by the next one.
+	start := time.Now()
+	response, err := c.Secrets(ctx).Sync(organizationId, snapshot.synced)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.HasChanges || snapshot.synced == nil {
+		snapshot.secrets = response.Secrets
+	}
+	tflog.SubsystemDebug(ctx, logSubsystemClient, "Synced Bitwarden secrets", map[string]any{
+		"organization_id": organizationId,
+		"has_changes":     response.HasChanges,
+		"secrets":         len(snapshot.secrets),
+	})
+	snapshot.synced = &start
+	snapshot.checked = start
+
+	return snapshot.secrets, nil
+}
+
+// invalidate drops every cached read and synced snapshot after a write.
+func (c *apiClient) invalidate() {
+	if c.cache != nil {
+		c.cache.invalidate()
+	}
+
+	c.syncsMu.Lock()
+	defer c.syncsMu.Unlock()
+	c.syncs = map[string]*secretSnapshot{}
+}
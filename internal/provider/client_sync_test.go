@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSyncedSecrets(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	first := fake.addSecret(testOrganizationId, project.ID, "FIRST", "1")
+	client := newTestAPIClient(fake)
+	ctx := context.Background()
+
+	secrets, err := client.SyncedSecrets(ctx, testOrganizationId)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(secrets) != 1 || secrets[0].ID != first.ID {
+		t.Fatalf("expected the first secret, got %v", secrets)
+	}
+
+	// Nothing changed: the snapshot is reused.
+	secrets, err = client.SyncedSecrets(ctx, testOrganizationId)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(secrets) != 1 {
+		t.Fatalf("expected the snapshot to be reused, got %v", secrets)
+	}
+
+	// A change made outside of the provider is picked up by the next sync.
+	time.Sleep(time.Millisecond)
+	fake.addSecret(testOrganizationId, project.ID, "SECOND", "2")
+	secrets, err = client.SyncedSecrets(ctx, testOrganizationId)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(secrets) != 2 {
+		t.Fatalf("expected both secrets after the change, got %v", secrets)
+	}
+}
+
+func TestSyncedSecretsReadCache(t *testing.T) {
+	fake := newFakeClient()
+	project := fake.addProject(testOrganizationId, "project")
+	fake.addSecret(testOrganizationId, project.ID, "FIRST", "1")
+	client := newTestCachedAPIClient(fake, time.Minute)
+	ctx := context.Background()
+	setupCalls := fake.callCount()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.SyncedSecrets(ctx, testOrganizationId); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	if calls := fake.callCount() - setupCalls; calls != 1 {
+		t.Fatalf("expected a single sync while the cache is fresh, got %d calls", calls)
+	}
+
+	// Writes made through the provider drop the snapshot.
+	if _, err := client.Secrets(ctx).Create("SECOND", "2", "", testOrganizationId, []string{project.ID}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	secrets, err := client.SyncedSecrets(ctx, testOrganizationId)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(secrets) != 2 {
+		t.Fatalf("expected the created secret, got %v", secrets)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	bitwarden "github.com/bitwarden/sdk-go"
+)
+
+func TestAPIClientRetries(t *testing.T) {
+	rateLimited := errors.New("API error: Received error message from server: [429 Too Many Requests]")
+	unavailable := errors.New("API error: Received error message from server: [503 Service Unavailable]")
+	notFound := errors.New("API error: Resource not found.")
+	throttled := errors.New("API error: Received error message from server: too many requests")
+	refused := errors.New("error sending request: connection refused")
+	identifier := errors.New("API error: Secret 4295a0c2-5031-4e02-b504-0b6a5e6f0a1d not found.")
+
+	testCases := map[string]struct {
+		errors      []error
+		create      bool
+		expectErr   bool
+		expectCalls int
+	}{
+		"success":              {expectCalls: 1},
+		"rate limited":         {errors: []error{rateLimited, rateLimited}, expectCalls: 3},
+		"unavailable":          {errors: []error{unavailable}, expectCalls: 2},
+		"not retryable":        {errors: []error{notFound}, expectErr: true, expectCalls: 1},
+		"retries exhausted":    {errors: []error{rateLimited, rateLimited, rateLimited, rateLimited}, expectErr: true, expectCalls: 4},
+		"create rate limited":  {errors: []error{rateLimited}, create: true, expectCalls: 2},
+		"create unavailable":   {errors: []error{unavailable}, create: true, expectErr: true, expectCalls: 1},
+		"create throttled":     {errors: []error{throttled}, create: true, expectErr: true, expectCalls: 1},
+		"create refused":       {errors: []error{refused}, create: true, expectCalls: 2},
+		"status in identifier": {errors: []error{identifier}, expectErr: true, expectCalls: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fake := newFakeClient()
+			project := fake.addProject(testOrganizationId, "project")
+			fake.errors = testCase.errors
+			fake.calls = 0
+
+			client := newAPIClient([]bitwarden.BitwardenClientInterface{fake}, clientOptions{maxRetries: 3, minBackoff: time.Millisecond, maxBackoff: 5 * time.Millisecond})
+
+			var err error
+			if testCase.create {
+				_, err = client.Projects(context.Background()).Create(testOrganizationId, "other")
+			} else {
+				_, err = client.Projects(context.Background()).Get(project.ID)
+			}
+
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls := fake.callCount(); calls != testCase.expectCalls {
+				t.Fatalf("expected %d calls, got %d", testCase.expectCalls, calls)
+			}
+		})
+	}
+}
+
+func TestAPIClientRetryCancelled(t *testing.T) {
+	fake := newFakeClient()
+	fake.errors = []error{errors.New("429 Too Many Requests")}
+
+	client := newAPIClient([]bitwarden.BitwardenClientInterface{fake}, clientOptions{maxRetries: 3, minBackoff: time.Hour, maxBackoff: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.Secrets(ctx).List(testOrganizationId); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the retry to stop with the context, got: %v", err)
+	}
+}
+
+func TestAPIClientBackoff(t *testing.T) {
+	client := newAPIClient([]bitwarden.BitwardenClientInterface{newFakeClient()}, clientOptions{minBackoff: time.Second, maxBackoff: 10 * time.Second})
+
+	for attempt, maximum := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		backoff := client.backoff(attempt)
+		if backoff < maximum/2 || backoff > maximum {
+			t.Errorf("attempt %d: expected a backoff between %s and %s, got %s", attempt, maximum/2, maximum, backoff)
+		}
+	}
+
+	if backoff := client.backoff(100); backoff > 10*time.Second {
+		t.Errorf("expected the backoff to be capped, got %s", backoff)
+	}
+}
+
+func TestAPIClientRateLimit(t *testing.T) {
+	fake := newFakeClient()
+	client := newAPIClient([]bitwarden.BitwardenClientInterface{fake}, clientOptions{requestsPerSecond: 20})
+
+	// The first 20 calls use the burst, the 5 following ones wait 50ms each.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Secrets(context.Background()).List(testOrganizationId); err != nil {
+				t.Errorf("err: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected the calls to be throttled, took %s", elapsed)
+	}
+	if calls := fake.callCount(); calls != 25 {
+		t.Fatalf("expected 25 calls, got %d", calls)
+	}
+}
+
+// exclusiveClient shares the data of a fakeClient but tracks its own use
+// without any locking, so the race detector flags concurrent calls.
+type exclusiveClient struct {
+	*fakeClient
+	busy     bool
+	overlaps int
+	uses     int
+}
+
+func (c *exclusiveClient) Secrets() bitwarden.SecretsInterface {
+	return exclusiveSecrets{fakeSecrets{c.fakeClient}, c}
+}
+
+func (c *exclusiveClient) enter() {
+	if c.busy {
+		c.overlaps++
+	}
+	c.busy = true
+	c.uses++
+}
+
+func (c *exclusiveClient) leave() {
+	c.busy = false
+}
+
+type exclusiveSecrets struct {
+	fakeSecrets
+	owner *exclusiveClient
+}
+
+func (s exclusiveSecrets) Create(key, value, note string, organizationID string, projectIDs []string) (*bitwarden.SecretResponse, error) {
+	s.owner.enter()
+	defer s.owner.leave()
+	time.Sleep(100 * time.Microsecond)
+	return s.fakeSecrets.Create(key, value, note, organizationID, projectIDs)
+}
+
+func (s exclusiveSecrets) Get(secretID string) (*bitwarden.SecretResponse, error) {
+	s.owner.enter()
+	defer s.owner.leave()
+	time.Sleep(100 * time.Microsecond)
+	return s.fakeSecrets.Get(secretID)
+}
+
+func TestAPIClientPool(t *testing.T) {
+	for _, poolSize := range []int{1, 3} {
+		fake := newFakeClient()
+		project := fake.addProject(testOrganizationId, "project")
+
+		var clients []*exclusiveClient
+		var sdkClients []bitwarden.BitwardenClientInterface
+		for i := 0; i < poolSize; i++ {
+			client := &exclusiveClient{fakeClient: fake}
+			clients = append(clients, client)
+			sdkClients = append(sdkClients, client)
+		}
+		client := newAPIClient(sdkClients, clientOptions{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx := context.Background()
+				secret, err := client.Secrets(ctx).Create("key", "value", "", testOrganizationId, []string{project.ID})
+				if err != nil {
+					t.Errorf("err: %s", err)
+					return
+				}
+				if _, err := client.Secrets(ctx).Get(secret.ID); err != nil {
+					t.Errorf("err: %s", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		uses := 0
+		for i, client := range clients {
+			if client.overlaps != 0 {
+				t.Errorf("pool of %d: client %d was used by %d concurrent calls", poolSize, i, client.overlaps)
+			}
+			uses += client.uses
+		}
+		if uses != 100 {
+			t.Errorf("pool of %d: expected 100 calls, got %d", poolSize, uses)
+		}
+	}
+}
+
+func TestAPIClientPoolCancelled(t *testing.T) {
+	client := newTestAPIClient(newFakeClient())
+	borrowed, err := client.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer client.release(borrowed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Secrets(ctx).List(testOrganizationId); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the call to give up waiting for a client, got %v", err)
+	}
+}
+
+// slowClient is a fakeClient whose secret and project reads block until
+// released. When started is set, every secret read sends to it once it is
+// blocked.
+type slowClient struct {
+	*fakeClient
+	release chan struct{}
+	started chan struct{}
+}
+
+func (c *slowClient) Secrets() bitwarden.SecretsInterface {
+	return slowSecrets{fakeSecrets{c.fakeClient}, c.release, c.started}
+}
+
+func (c *slowClient) Projects() bitwarden.ProjectsInterface {
+	return slowProjects{fakeProjects{c.fakeClient}, c.release}
+}
+
+type slowProjects struct {
+	fakeProjects
+	release chan struct{}
+}
+
+func (p slowProjects) Get(projectID string) (*bitwarden.ProjectResponse, error) {
+	<-p.release
+	return p.fakeProjects.Get(projectID)
+}
+
+type slowSecrets struct {
+	fakeSecrets
+	release chan struct{}
+	started chan struct{}
+}
+
+func (s slowSecrets) Get(secretID string) (*bitwarden.SecretResponse, error) {
+	if s.started != nil {
+		s.started <- struct{}{}
+	}
+	<-s.release
+	return s.fakeSecrets.Get(secretID)
+}
+
+func TestAPIClientTimeout(t *testing.T) {
+	fake := &slowClient{fakeClient: newFakeClient(), release: make(chan struct{})}
+	project := fake.addProject(testOrganizationId, "project")
+	secret := fake.addSecret(testOrganizationId, project.ID, "KEY", "value")
+	client := newTestAPIClient(fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Secrets(ctx).Get(secret.ID)
+	var timeoutErr *callTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+
+	// The client stays borrowed by the abandoned call until it returns.
+	if _, err := client.acquire(ctx); err == nil {
+		t.Fatal("expected the abandoned call to hold the client")
+	}
+	close(fake.release)
+	if _, err := client.Secrets(context.Background()).Get(secret.ID); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
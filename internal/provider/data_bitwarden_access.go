@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &accessDataSource{}
+	_ datasource.DataSourceWithConfigure = &accessDataSource{}
+)
+
+// NewAccessDataSource is a helper function to simplify the provider implementation.
+func NewAccessDataSource() datasource.DataSource {
+	return &accessDataSource{}
+}
+
+// accessDataSource is the data source implementation.
+type accessDataSource struct {
+	client           *apiClient
+	organizationId   string
+	serviceAccountId string
+}
+
+// accessDataSourceModel maps the data source schema data.
+type accessDataSourceModel struct {
+	ID               types.String         `tfsdk:"id"`
+	OrganizationId   types.String         `tfsdk:"organization_id"`
+	MachineAccountId types.String         `tfsdk:"machine_account_id"`
+	Projects         []accessProjectModel `tfsdk:"projects"`
+	ProjectIds       []types.String       `tfsdk:"project_ids"`
+}
+
+type accessProjectModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (p accessDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_access"
+}
+
+func (p accessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Lists the projects of an organization the machine account can see, for use in `check` blocks. " +
+			"Bitwarden doesn't tell read-only from read/write access, so a listed project may still refuse writes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Id of the organization",
+				Computed:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Organization to list the projects of, defaults to the provider organization_id",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+			"machine_account_id": schema.StringAttribute{
+				Description: "Id of the machine account of the provider access token",
+				Computed:    true,
+			},
+			"projects": schema.ListNestedAttribute{
+				Description: "Projects the machine account can see, sorted by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Id of the project",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the project",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"project_ids": schema.ListAttribute{
+				Description: "Ids of the projects the machine account can see, sorted by project name",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (p *accessDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	p.client = providerData.client
+	p.organizationId = providerData.organizationId
+	p.serviceAccountId = providerData.serviceAccountId
+}
+
+func (p accessDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var info accessDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &info)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	organizationId := p.organizationId
+	if !info.OrganizationId.IsNull() {
+		organizationId = info.OrganizationId.ValueString()
+	}
+	if organizationId == "" {
+		response.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Missing organization id",
+			"Set organization_id on the data source or on the provider.",
+		)
+		return
+	}
+
+	projects, err := accessibleProjects(ctx, p.client, organizationId)
+	if err != nil {
+		addAPIError(&response.Diagnostics, "list the projects accessible to the machine account", err)
+		return
+	}
+
+	info.Projects = make([]accessProjectModel, 0, len(projects))
+	for id, name := range projects {
+		info.Projects = append(info.Projects, accessProjectModel{Id: types.StringValue(id), Name: types.StringValue(name)})
+	}
+	sort.Slice(info.Projects, func(i, j int) bool {
+		if info.Projects[i].Name.ValueString() != info.Projects[j].Name.ValueString() {
+			return info.Projects[i].Name.ValueString() < info.Projects[j].Name.ValueString()
+		}
+		return info.Projects[i].Id.ValueString() < info.Projects[j].Id.ValueString()
+	})
+
+	info.ProjectIds = make([]types.String, 0, len(info.Projects))
+	for _, project := range info.Projects {
+		info.ProjectIds = append(info.ProjectIds, project.Id)
+	}
+
+	info.ID = types.StringValue(organizationId)
+	info.OrganizationId = types.StringValue(organizationId)
+	info.MachineAccountId = types.StringValue(p.serviceAccountId)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &info)...)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAccessDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	beta := client.addProject(testOrganizationId, "beta")
+	alpha := client.addProject(testOrganizationId, "alpha")
+	client.addProject("0a9d8c7b-6e5f-4a3b-9c2d-1e0f9a8b7c6d", "other organization")
+
+	dataSource := &accessDataSource{
+		client:           newTestAPIClient(client),
+		organizationId:   testOrganizationId,
+		serviceAccountId: "5e3c8b2a-1f4d-4c6e-9a7b-2d8f0e1c3b5a",
+	}
+
+	schemaResponse := &datasource.SchemaResponse{}
+	dataSource.Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+	objectType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	configValues := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		configValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	config := tfsdk.Config{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(objectType, configValues)}
+
+	response := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	dataSource.Read(ctx, datasource.ReadRequest{Config: config}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	var state accessDataSourceModel
+	response.Diagnostics.Append(response.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	expected := []string{alpha.ID, beta.ID}
+	if len(state.ProjectIds) != len(expected) {
+		t.Fatalf("expected the projects of the organization, got %v", state.ProjectIds)
+	}
+	for i, projectId := range expected {
+		if !state.ProjectIds[i].Equal(types.StringValue(projectId)) || !state.Projects[i].Id.Equal(types.StringValue(projectId)) {
+			t.Fatalf("expected the projects sorted by name, got %v", state.Projects)
+		}
+	}
+	if state.OrganizationId.ValueString() != testOrganizationId || state.MachineAccountId.ValueString() != dataSource.serviceAccountId {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+}
@@ -6,10 +6,13 @@ package provider
 import (
 	"context"
 	"fmt"
-	bitwarden "github.com/bitwarden/sdk-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -25,7 +28,7 @@ func NewProjectDataSource() datasource.DataSource {
 
 // projectDataSource is the data source implementation.
 type projectDataSource struct {
-	client bitwarden.BitwardenClientInterface
+	client *apiClient
 }
 
 // projectDataSourceModel maps the data source schema data.
@@ -56,30 +59,31 @@ func (p projectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			},
 			"projects": schema.ListNestedAttribute{
 				Description: "List of projects.",
-				Computed:    true,
+				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"CreationDate": schema.StringAttribute{
+						"creation_date": schema.StringAttribute{
 							Description: "Creation date of the project",
 							Computed:    true,
-							Optional:    true,
 						},
-						"Name": schema.StringAttribute{
+						"name": schema.StringAttribute{
 							Description: "Name of the project",
 							Computed:    true,
 						},
-						"ID": schema.StringAttribute{
+						"id": schema.StringAttribute{
 							Description: "Id of the project",
-							Computed:    true,
+							Required:    true,
+							Validators: []validator.String{
+								validators.UUID(),
+							},
 						},
-						"OrganizationID": schema.StringAttribute{
+						"organization_id": schema.StringAttribute{
 							Description: "organization ID associated with the project",
 							Computed:    true,
 						},
-						"RevisionDate": schema.StringAttribute{
+						"revision_date": schema.StringAttribute{
 							Description: "Last date the project was updated/revised",
 							Computed:    true,
-							Optional:    true,
 						},
 					},
 				},
@@ -94,32 +98,33 @@ func (p *projectDataSource) Configure(_ context.Context, request datasource.Conf
 		return
 	}
 
-	client, ok := request.ProviderData.(bitwarden.BitwardenClientInterface)
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bitwarden.BitwardenClientInterface, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	p.client = client
+	p.client = providerData.client
 }
 
 func (p projectDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
 	var info projectDataSourceModel
 
-	request.Config.Get(ctx, &info)
+	response.Diagnostics.Append(request.Config.Get(ctx, &info)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
-	for _, projectInfo := range info.Projects {
-		project, err := p.client.Projects().Get(projectInfo.Id.ValueString())
+	var projectIds []string
+	for projectIndex, projectInfo := range info.Projects {
+		project, err := p.client.Projects(ctx).Get(projectInfo.Id.ValueString())
 
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Unable to list projects under organization id",
-				"Validate that the organization id is not empty and is valid.",
-			)
+			addAttributeAPIError(&response.Diagnostics, path.Root("projects").AtListIndex(projectIndex).AtName("id"), "read project", err)
 
 			return
 		}
@@ -130,8 +135,10 @@ func (p projectDataSource) Read(ctx context.Context, request datasource.ReadRequ
 			OrganizationId: types.StringValue(project.OrganizationID),
 			RevisionDate:   types.StringValue(project.RevisionDate),
 		}
-		info.Projects = append(info.Projects, secretModel)
+		info.Projects[projectIndex] = secretModel
+		projectIds = append(projectIds, project.ID)
 	}
+	info.ID = types.StringValue(strings.Join(projectIds, ","))
 
 	diags := response.State.Set(ctx, &info)
 
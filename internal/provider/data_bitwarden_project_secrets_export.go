@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+)
+
+const (
+	exportFormatDotenv      = "dotenv"
+	exportFormatJSON        = "json"
+	exportFormatYAML        = "yaml"
+	exportFormatShellExport = "shell-export"
+)
+
+// invalidEnvKeyChars matches every character that is not allowed in an
+// environment variable name.
+var invalidEnvKeyChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// envKeyChars matches a non-empty string of characters allowed in an
+// environment variable name.
+var envKeyChars = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &projectSecretsExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &projectSecretsExportDataSource{}
+)
+
+// NewProjectSecretsExportDataSource is a helper function to simplify the provider implementation.
+func NewProjectSecretsExportDataSource() datasource.DataSource {
+	return &projectSecretsExportDataSource{}
+}
+
+// projectSecretsExportDataSource is the data source implementation.
+type projectSecretsExportDataSource struct {
+	client *apiClient
+}
+
+// projectSecretsExportDataSourceModel maps the data source schema data.
+type projectSecretsExportDataSourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	ProjectId              types.String `tfsdk:"project_id"`
+	Format                 types.String `tfsdk:"format"`
+	UpperCase              types.Bool   `tfsdk:"upper_case"`
+	Prefix                 types.String `tfsdk:"prefix"`
+	InvalidCharReplacement types.String `tfsdk:"invalid_char_replacement"`
+	Content                types.String `tfsdk:"content"`
+}
+
+// exportKeyOptions describes how secret keys are turned into variable names.
+type exportKeyOptions struct {
+	upperCase              bool
+	prefix                 string
+	invalidCharReplacement string
+}
+
+func (p projectSecretsExportDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_project_secrets_export"
+}
+
+func (p projectSecretsExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Renders every secret of a project as a dotenv, JSON, YAML or shell-export document, using the same variable names `bws run` injects.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Id of the exported project",
+				Computed:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "Id of the project to export the secrets from",
+				Required:    true,
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Description: "Output format, one of `dotenv`, `json`, `yaml` or `shell-export`. Defaults to `dotenv`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.OneOf(exportFormatDotenv, exportFormatJSON, exportFormatYAML, exportFormatShellExport),
+				},
+			},
+			"upper_case": schema.BoolAttribute{
+				Description: "Upper-case every variable name",
+				Optional:    true,
+			},
+			"prefix": schema.StringAttribute{
+				Description: "Prefix added to every variable name",
+				Optional:    true,
+			},
+			"invalid_char_replacement": schema.StringAttribute{
+				Description: "Replacement for characters that are not valid in an environment variable name, made of one or more letters, digits or underscores. Defaults to `_`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Regexp(envKeyChars, "one or more letters, digits or underscores"),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Rendered secrets",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (p *projectSecretsExportDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	p.client = providerData.client
+}
+
+func (p projectSecretsExportDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var info projectSecretsExportDataSourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &info)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	format := exportFormatDotenv
+	if !info.Format.IsNull() {
+		format = info.Format.ValueString()
+	}
+
+	options := exportKeyOptions{
+		upperCase:              info.UpperCase.ValueBool(),
+		prefix:                 info.Prefix.ValueString(),
+		invalidCharReplacement: "_",
+	}
+	if !info.InvalidCharReplacement.IsNull() {
+		options.invalidCharReplacement = info.InvalidCharReplacement.ValueString()
+	}
+
+	projectId := info.ProjectId.ValueString()
+	project, err := p.client.Projects(ctx).Get(projectId)
+	if err != nil {
+		addAttributeAPIError(&response.Diagnostics, path.Root("project_id"), "read project", err)
+		return
+	}
+
+	organizationSecrets, err := p.client.SyncedSecrets(ctx, project.OrganizationID)
+	if err != nil {
+		addAPIError(&response.Diagnostics, "sync the secrets of the project organization", err)
+		return
+	}
+
+	var secrets []bitwarden.SecretResponse
+	for _, secret := range organizationSecrets {
+		if secret.ProjectID != nil && *secret.ProjectID == projectId {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	variables, err := exportVariables(secrets, options)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to export secrets",
+			err.Error(),
+		)
+		return
+	}
+
+	content, err := renderExport(variables, format)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to export secrets",
+			"Could not render the secrets, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	info.ID = types.StringValue(projectId)
+	info.Content = types.StringValue(content)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &info)...)
+}
+
+// exportKey turns a secret key into a variable name.
+func exportKey(key string, options exportKeyOptions) string {
+	name := invalidEnvKeyChars.ReplaceAllString(options.prefix+key, options.invalidCharReplacement)
+	if options.upperCase {
+		name = strings.ToUpper(name)
+	}
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = options.invalidCharReplacement + name
+	}
+	return name
+}
+
+// exportVariables maps secrets to variable names, failing when two secrets
+// end up with the same name.
+func exportVariables(secrets []bitwarden.SecretResponse, options exportKeyOptions) (map[string]string, error) {
+	variables := make(map[string]string, len(secrets))
+	origins := make(map[string]string, len(secrets))
+
+	for _, secret := range secrets {
+		name := exportKey(secret.Key, options)
+		if name == "" {
+			return nil, fmt.Errorf("the secret %q (%s) has no usable variable name", secret.Key, secret.ID)
+		}
+		if origin, ok := origins[name]; ok {
+			return nil, fmt.Errorf("the secrets %q and %q both export as %s, rename one of them or adjust the key transforms", origin, secret.Key, name)
+		}
+		origins[name] = secret.Key
+		variables[name] = secret.Value
+	}
+
+	return variables, nil
+}
+
+// renderExport renders the variables in the requested format, sorted by name
+// so the content is stable between runs.
+func renderExport(variables map[string]string, format string) (string, error) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var content strings.Builder
+	switch format {
+	case exportFormatJSON:
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(variables); err != nil {
+			return "", err
+		}
+		return buffer.String(), nil
+	case exportFormatYAML:
+		if len(names) == 0 {
+			return "{}\n", nil
+		}
+		for _, name := range names {
+			fmt.Fprintf(&content, "%s: %s\n", strconv.Quote(name), strconv.Quote(variables[name]))
+		}
+	case exportFormatShellExport:
+		for _, name := range names {
+			fmt.Fprintf(&content, "export %s='%s'\n", name, strings.ReplaceAll(variables[name], "'", `'\''`))
+		}
+	case exportFormatDotenv:
+		// Shells and dotenv loaders expand $ and backticks in double quotes.
+		replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`, "\r", `\r`)
+		for _, name := range names {
+			fmt.Fprintf(&content, "%s=\"%s\"\n", name, replacer.Replace(variables[name]))
+		}
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+
+	return content.String(), nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExportVariables(t *testing.T) {
+	secrets := []bitwarden.SecretResponse{
+		{ID: "1", Key: "db-password", Value: "s3cr3t"},
+		{ID: "2", Key: "1st.token", Value: "abc"},
+	}
+
+	variables, err := exportVariables(secrets, exportKeyOptions{upperCase: true, prefix: "app_", invalidCharReplacement: "_"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if variables["APP_DB_PASSWORD"] != "s3cr3t" || variables["APP_1ST_TOKEN"] != "abc" {
+		t.Fatalf("unexpected variables: %v", variables)
+	}
+
+	variables, err = exportVariables(secrets[1:], exportKeyOptions{invalidCharReplacement: "_"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, ok := variables["_1st_token"]; !ok {
+		t.Fatalf("expected a leading digit to be escaped: %v", variables)
+	}
+
+	duplicates := []bitwarden.SecretResponse{
+		{ID: "1", Key: "api-key", Value: "a"},
+		{ID: "2", Key: "api.key", Value: "b"},
+	}
+	if _, err := exportVariables(duplicates, exportKeyOptions{invalidCharReplacement: "_"}); err == nil {
+		t.Fatal("expected an error for keys exporting to the same name")
+	}
+}
+
+func TestProjectSecretsExportInvalidCharReplacement(t *testing.T) {
+	response := &datasource.SchemaResponse{}
+	projectSecretsExportDataSource{}.Schema(context.Background(), datasource.SchemaRequest{}, response)
+	attribute := response.Schema.Attributes["invalid_char_replacement"].(schema.StringAttribute)
+
+	testCases := map[string]struct {
+		value     string
+		expectErr bool
+	}{
+		"underscore":        {value: "_"},
+		"letters":           {value: "X"},
+		"empty":             {value: "", expectErr: true},
+		"command":           {value: "$(id)", expectErr: true},
+		"command separator": {value: ";", expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validateResponse := &validator.StringResponse{}
+			for _, stringValidator := range attribute.Validators {
+				stringValidator.ValidateString(context.Background(), validator.StringRequest{
+					Path:        path.Root("invalid_char_replacement"),
+					ConfigValue: types.StringValue(testCase.value),
+				}, validateResponse)
+			}
+
+			if validateResponse.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", validateResponse.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestRenderExport(t *testing.T) {
+	variables := map[string]string{
+		"B": "it's \"quoted\"\nmulti",
+		"A": "<plain>",
+		"C": "$HOME `id`",
+	}
+
+	testCases := map[string]string{
+		exportFormatDotenv:      "A=\"<plain>\"\nB=\"it's \\\"quoted\\\"\\nmulti\"\nC=\"\\$HOME \\`id\\`\"\n",
+		exportFormatJSON:        "{\n  \"A\": \"<plain>\",\n  \"B\": \"it's \\\"quoted\\\"\\nmulti\",\n  \"C\": \"$HOME `id`\"\n}\n",
+		exportFormatYAML:        "\"A\": \"<plain>\"\n\"B\": \"it's \\\"quoted\\\"\\nmulti\"\n\"C\": \"$HOME `id`\"\n",
+		exportFormatShellExport: "export A='<plain>'\nexport B='it'\\''s \"quoted\"\nmulti'\nexport C='$HOME `id`'\n",
+	}
+
+	for format, expected := range testCases {
+		content, err := renderExport(variables, format)
+		if err != nil {
+			t.Fatalf("%s: err: %s", format, err)
+		}
+		if content != expected {
+			t.Errorf("%s: expected %q, got %q", format, expected, content)
+		}
+	}
+
+	if _, err := renderExport(variables, "toml"); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
@@ -9,7 +9,12 @@ import (
 	bitwarden "github.com/bitwarden/sdk-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -25,7 +30,12 @@ func NewSecretDataSource() datasource.DataSource {
 
 // secretDataSource is the data source implementation.
 type secretDataSource struct {
-	client bitwarden.BitwardenClientInterface
+	client *apiClient
+	// organizationId is the provider default organization, whose synced
+	// secrets are used to read several secrets at once.
+	organizationId string
+	// valueChecksumKey keys the value_sha256 checksums, nil for plain SHA-256.
+	valueChecksumKey []byte
 }
 
 // secretDataSourceModel maps the data source schema data.
@@ -38,6 +48,7 @@ type secretModel struct {
 	Id             types.String `tfsdk:"id"`
 	Key            types.String `tfsdk:"key"`
 	Value          types.String `tfsdk:"value"`
+	ValueSha256    types.String `tfsdk:"value_sha256"`
 	Note           types.String `tfsdk:"note"`
 	OrganizationId types.String `tfsdk:"organization_id"`
 	ProjectId      types.String `tfsdk:"project_id"`
@@ -56,11 +67,11 @@ func (p secretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:    true,
 			},
 			"secrets": schema.ListNestedAttribute{
-				Description: "List of projects.",
-				Computed:    true,
+				Description: "List of secrets.",
+				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"Key": schema.StringAttribute{
+						"key": schema.StringAttribute{
 							Description: "Key/Name of the secret",
 							Computed:    true,
 						},
@@ -69,27 +80,30 @@ func (p secretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Computed:    true,
 							Sensitive:   true,
 						},
+						"value_sha256": schema.StringAttribute{
+							Description: "SHA-256 checksum of the value, an HMAC-SHA256 when the provider value_checksum_key is set",
+							Computed:    true,
+						},
 						"note": schema.StringAttribute{
 							Description: "note for the secret",
 							Computed:    true,
 							Sensitive:   true,
 						},
-						"ID": schema.StringAttribute{
+						"id": schema.StringAttribute{
 							Description: "Id of the secret",
-							Computed:    true,
+							Required:    true,
+							Validators: []validator.String{
+								validators.UUID(),
+							},
 						},
-						"ProjectId": schema.StringAttribute{
+						"project_id": schema.StringAttribute{
 							Description: "Id of the project",
 							Computed:    true,
 						},
-						"OrganizationID": schema.StringAttribute{
+						"organization_id": schema.StringAttribute{
 							Description: "organization ID associated with the project",
 							Computed:    true,
 						},
-						"RevisionDate": schema.StringAttribute{
-							Description: "Last date the project was updated/revised",
-							Computed:    true,
-						},
 					},
 				},
 			},
@@ -103,45 +117,57 @@ func (p *secretDataSource) Configure(_ context.Context, request datasource.Confi
 		return
 	}
 
-	client, ok := request.ProviderData.(bitwarden.BitwardenClientInterface)
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *bitwarden.BitwardenClientInterface, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	p.client = client
+	p.client = providerData.client
+	p.organizationId = providerData.organizationId
+	p.valueChecksumKey = providerData.valueChecksumKey
 }
 
 func (p secretDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
 	var info secretDataSourceModel
 
-	request.Config.Get(ctx, &info)
+	response.Diagnostics.Append(request.Config.Get(ctx, &info)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	synced := p.syncedSecrets(ctx, len(info.Secrets))
 
-	for _, secretInfo := range info.Secrets {
-		secret, err := p.client.Secrets().Get(secretInfo.Id.ValueString())
+	var secretIds []string
+	for secretIndex, secretInfo := range info.Secrets {
+		secret, ok := synced[secretInfo.Id.ValueString()]
+		var err error
+		if !ok {
+			secret, err = p.client.Secrets(ctx).Get(secretInfo.Id.ValueString())
+		}
 
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Unable to list secrets under organization id",
-				"Validate that the organization id is not empty and is valid.",
-			)
+			addAttributeAPIError(&response.Diagnostics, path.Root("secrets").AtListIndex(secretIndex).AtName("id"), "read secret", err)
 
 			return
 		}
-		secretModel := secretModel{
-			Key:            types.StringValue(secret.Key),
-			Value:          types.StringValue(secret.Value),
-			Note:           types.StringValue(secret.Note),
-			ProjectId:      types.StringValue(*secret.ProjectID),
-			OrganizationId: types.StringValue(secret.OrganizationID),
-			Id:             types.StringValue(secret.ID),
+		secretItem := newSecretItemModel(secret, p.valueChecksumKey)
+		info.Secrets[secretIndex] = secretModel{
+			Key:            secretItem.Key,
+			Value:          secretItem.Value,
+			ValueSha256:    secretItem.ValueSha256,
+			Note:           secretItem.Note,
+			ProjectId:      secretItem.ProjectId,
+			OrganizationId: secretItem.OrganizationId,
+			Id:             secretItem.SecretId,
 		}
-		info.Secrets = append(info.Secrets, secretModel)
+		secretIds = append(secretIds, secret.ID)
 	}
+	info.ID = types.StringValue(strings.Join(secretIds, ","))
 	diags := response.State.Set(ctx, &info)
 
 	response.Diagnostics.Append(diags...)
@@ -149,3 +175,26 @@ func (p secretDataSource) Read(ctx context.Context, request datasource.ReadReque
 		return
 	}
 }
+
+// syncedSecrets indexes the synced secrets of the provider organization by
+// id when several secrets are read, so they don't need one call each. The
+// secrets missing from it, or every secret when the sync fails, are read
+// one by one.
+func (p secretDataSource) syncedSecrets(ctx context.Context, count int) map[string]*bitwarden.SecretResponse {
+	if p.organizationId == "" || count < 2 {
+		return nil
+	}
+
+	secrets, err := p.client.SyncedSecrets(ctx, p.organizationId)
+	if err != nil {
+		ctx = newLogSubsystem(ctx, logSubsystemClient)
+		tflog.SubsystemDebug(ctx, logSubsystemClient, "Unable to sync secrets, reading them one by one", map[string]any{"error": err.Error()})
+		return nil
+	}
+
+	synced := make(map[string]*bitwarden.SecretResponse, len(secrets))
+	for i := range secrets {
+		synced[secrets[i].ID] = &secrets[i]
+	}
+	return synced
+}
@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/go-uuid"
+)
+
+// testOrganizationId is the organization used by unit tests.
+const testOrganizationId = "f3f1a2b4-9c6d-4e2a-8b7f-1d0e5c4b3a29"
+
+// fakeClient is an in-memory bitwarden.BitwardenClientInterface used by unit tests.
+type fakeClient struct {
+	mu       sync.Mutex
+	projects map[string]bitwarden.ProjectResponse
+	secrets  map[string]bitwarden.SecretResponse
+	// deleteErrors are returned as per-id errors by batch deletes.
+	deleteErrors map[string]string
+	// errors are returned, in order, by the next project and secret calls.
+	errors []error
+	// calls counts the project and secret calls.
+	calls int
+	// revision is when a secret last changed, compared with the date of syncs.
+	revision time.Time
+}
+
+var _ bitwarden.BitwardenClientInterface = &fakeClient{}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		projects: map[string]bitwarden.ProjectResponse{},
+		secrets:  map[string]bitwarden.SecretResponse{},
+
+		deleteErrors: map[string]string{},
+	}
+}
+
+func (c *fakeClient) AccessTokenLogin(_ string, _ *string) error {
+	return nil
+}
+
+func (c *fakeClient) Projects() bitwarden.ProjectsInterface {
+	return fakeProjects{c}
+}
+
+func (c *fakeClient) Secrets() bitwarden.SecretsInterface {
+	return fakeSecrets{c}
+}
+
+func (c *fakeClient) Close() {}
+
+// addProject stores a project and returns it.
+func (c *fakeClient) addProject(organizationId string, name string) bitwarden.ProjectResponse {
+	project, _ := fakeProjects{c}.Create(organizationId, name)
+	return *project
+}
+
+// addSecret stores a secret and returns it.
+func (c *fakeClient) addSecret(organizationId string, projectId string, key string, value string) bitwarden.SecretResponse {
+	secret, _ := fakeSecrets{c}.Create(key, value, "", organizationId, []string{projectId})
+	return *secret
+}
+
+// newTestAPIClient wraps a client the way the provider does, without retries.
+func newTestAPIClient(client bitwarden.BitwardenClientInterface) *apiClient {
+	return newAPIClient([]bitwarden.BitwardenClientInterface{client}, clientOptions{})
+}
+
+// nextError records a call and returns the next injected error, if any. The
+// caller must hold c.mu.
+func (c *fakeClient) nextError() error {
+	c.calls++
+	if len(c.errors) == 0 {
+		return nil
+	}
+	err := c.errors[0]
+	c.errors = c.errors[1:]
+	return err
+}
+
+// callCount returns the number of project and secret calls made so far.
+func (c *fakeClient) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func fakeId() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func fakeNotFound(kind string, id string) error {
+	return fmt.Errorf("API error: %s not found: %s", kind, id)
+}
+
+type fakeProjects struct {
+	c *fakeClient
+}
+
+func (p fakeProjects) Create(organizationId string, name string) (*bitwarden.ProjectResponse, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+
+	if err := p.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	project := bitwarden.ProjectResponse{ID: fakeId(), Name: name, OrganizationID: organizationId}
+	p.c.projects[project.ID] = project
+	return &project, nil
+}
+
+func (p fakeProjects) List(organizationId string) (*bitwarden.ProjectsResponse, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+
+	if err := p.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	response := &bitwarden.ProjectsResponse{}
+	for _, project := range p.c.projects {
+		if project.OrganizationID == organizationId {
+			response.Data = append(response.Data, project)
+		}
+	}
+	return response, nil
+}
+
+func (p fakeProjects) Get(projectId string) (*bitwarden.ProjectResponse, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+
+	if err := p.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	project, ok := p.c.projects[projectId]
+	if !ok {
+		return nil, fakeNotFound("project", projectId)
+	}
+	return &project, nil
+}
+
+func (p fakeProjects) Update(projectId string, organizationId string, name string) (*bitwarden.ProjectResponse, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+
+	if err := p.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	project, ok := p.c.projects[projectId]
+	if !ok {
+		return nil, fakeNotFound("project", projectId)
+	}
+	project.Name = name
+	project.OrganizationID = organizationId
+	p.c.projects[projectId] = project
+	return &project, nil
+}
+
+func (p fakeProjects) Delete(projectIds []string) (*bitwarden.ProjectsDeleteResponse, error) {
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+
+	if err := p.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	response := &bitwarden.ProjectsDeleteResponse{}
+	for _, projectId := range projectIds {
+		item := bitwarden.ProjectDeleteResponse{ID: projectId}
+		if message, ok := p.c.deleteErrors[projectId]; ok {
+			item.Error = &message
+		} else if _, ok := p.c.projects[projectId]; !ok {
+			message := "Project not found"
+			item.Error = &message
+		} else {
+			delete(p.c.projects, projectId)
+		}
+		response.Data = append(response.Data, item)
+	}
+	return response, nil
+}
+
+type fakeSecrets struct {
+	c *fakeClient
+}
+
+func (s fakeSecrets) Create(key, value, note string, organizationId string, projectIds []string) (*bitwarden.SecretResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	secret := bitwarden.SecretResponse{ID: fakeId(), Key: key, Value: value, Note: note, OrganizationID: organizationId}
+	if len(projectIds) > 0 {
+		secret.ProjectID = &projectIds[0]
+	}
+	s.c.secrets[secret.ID] = secret
+	s.c.revision = time.Now()
+	return &secret, nil
+}
+
+func (s fakeSecrets) List(organizationId string) (*bitwarden.SecretIdentifiersResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	response := &bitwarden.SecretIdentifiersResponse{}
+	for _, secret := range s.c.secrets {
+		if secret.OrganizationID == organizationId {
+			response.Data = append(response.Data, bitwarden.SecretIdentifierResponse{ID: secret.ID, Key: secret.Key, OrganizationID: secret.OrganizationID})
+		}
+	}
+	return response, nil
+}
+
+func (s fakeSecrets) Get(secretId string) (*bitwarden.SecretResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	secret, ok := s.c.secrets[secretId]
+	if !ok {
+		return nil, fakeNotFound("secret", secretId)
+	}
+	return &secret, nil
+}
+
+func (s fakeSecrets) GetByIDS(secretIds []string) (*bitwarden.SecretsResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	response := &bitwarden.SecretsResponse{}
+	for _, secretId := range secretIds {
+		secret, ok := s.c.secrets[secretId]
+		if !ok {
+			return nil, fakeNotFound("secret", secretId)
+		}
+		response.Data = append(response.Data, secret)
+	}
+	return response, nil
+}
+
+func (s fakeSecrets) Update(secretId string, key, value, note string, organizationId string, projectIds []string) (*bitwarden.SecretResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	secret, ok := s.c.secrets[secretId]
+	if !ok {
+		return nil, fakeNotFound("secret", secretId)
+	}
+	secret.Key = key
+	secret.Value = value
+	secret.Note = note
+	secret.OrganizationID = organizationId
+	secret.ProjectID = nil
+	if len(projectIds) > 0 {
+		secret.ProjectID = &projectIds[0]
+	}
+	s.c.secrets[secretId] = secret
+	s.c.revision = time.Now()
+	return &secret, nil
+}
+
+func (s fakeSecrets) Delete(secretIds []string) (*bitwarden.SecretsDeleteResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	response := &bitwarden.SecretsDeleteResponse{}
+	for _, secretId := range secretIds {
+		item := bitwarden.SecretDeleteResponse{ID: secretId}
+		if message, ok := s.c.deleteErrors[secretId]; ok {
+			item.Error = &message
+		} else if _, ok := s.c.secrets[secretId]; !ok {
+			message := "Secret not found"
+			item.Error = &message
+		} else {
+			delete(s.c.secrets, secretId)
+			s.c.revision = time.Now()
+		}
+		response.Data = append(response.Data, item)
+	}
+	return response, nil
+}
+
+func (s fakeSecrets) Sync(organizationId string, lastSyncedDate *time.Time) (*bitwarden.SecretsSyncResponse, error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := s.c.nextError(); err != nil {
+		return nil, err
+	}
+
+	if lastSyncedDate != nil && !s.c.revision.After(*lastSyncedDate) {
+		return &bitwarden.SecretsSyncResponse{}, nil
+	}
+
+	response := &bitwarden.SecretsSyncResponse{HasChanges: true}
+	for _, secret := range s.c.secrets {
+		if secret.OrganizationID == organizationId {
+			response.Secrets = append(response.Secrets, secret)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// accessTokenInfoAttributeTypes describes the object returned by
+// access_token_info. The client secret and encryption key are never exposed.
+var accessTokenInfoAttributeTypes = map[string]attr.Type{
+	"version":            types.StringType,
+	"service_account_id": types.StringType,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &accessTokenInfoFunction{}
+
+// NewAccessTokenInfoFunction is a helper function to simplify the provider implementation.
+func NewAccessTokenInfoFunction() function.Function {
+	return &accessTokenInfoFunction{}
+}
+
+// accessTokenInfoFunction decodes the public parts of an access token.
+type accessTokenInfoFunction struct{}
+
+func (f *accessTokenInfoFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "access_token_info"
+}
+
+func (f *accessTokenInfoFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Decode a machine account access token",
+		Description: "Validates the format of a machine account access token and returns its `version` and `service_account_id`. The client secret and encryption key are never returned.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "token",
+				Description: "machine account access token",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: accessTokenInfoAttributeTypes,
+		},
+	}
+}
+
+func (f *accessTokenInfoFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var token string
+
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &token))
+	if response.Error != nil {
+		return
+	}
+
+	parsed, err := parseAccessToken(token)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(accessTokenInfoAttributeTypes, map[string]attr.Value{
+		"version":            types.StringValue(parsed.Version),
+		"service_account_id": types.StringValue(parsed.ServiceAccountId),
+	})
+	response.Error = function.ConcatFuncErrors(response.Error, function.FuncErrorFromDiags(ctx, diags))
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, result))
+}
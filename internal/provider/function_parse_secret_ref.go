@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretRefAttributeTypes describes the object returned by parse_secret_ref.
+var secretRefAttributeTypes = map[string]attr.Type{
+	"organization_id": types.StringType,
+	"project_id":      types.StringType,
+	"key":             types.StringType,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseSecretRefFunction{}
+
+// NewParseSecretRefFunction is a helper function to simplify the provider implementation.
+func NewParseSecretRefFunction() function.Function {
+	return &parseSecretRefFunction{}
+}
+
+// parseSecretRefFunction splits a secret reference into its parts.
+type parseSecretRefFunction struct{}
+
+func (f *parseSecretRefFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_secret_ref"
+}
+
+func (f *parseSecretRefFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Parse a secret reference",
+		Description: "Splits a reference built by `secret_ref` into an object with `organization_id`, `project_id` and `key` attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "ref",
+				Description: "secret reference to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: secretRefAttributeTypes,
+		},
+	}
+}
+
+func (f *parseSecretRefFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var ref string
+
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &ref))
+	if response.Error != nil {
+		return
+	}
+
+	parsed, err := parseSecretRef(ref)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(secretRefAttributeTypes, map[string]attr.Value{
+		"organization_id": types.StringValue(parsed.OrganizationId),
+		"project_id":      types.StringValue(parsed.ProjectId),
+		"key":             types.StringValue(parsed.Key),
+	})
+	response.Error = function.ConcatFuncErrors(response.Error, function.FuncErrorFromDiags(ctx, diags))
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, result))
+}
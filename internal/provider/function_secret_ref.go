@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"net/url"
+	"strings"
+)
+
+// secretRefScheme prefixes every canonical secret reference.
+const secretRefScheme = "bitwarden://"
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &secretRefFunction{}
+
+// NewSecretRefFunction is a helper function to simplify the provider implementation.
+func NewSecretRefFunction() function.Function {
+	return &secretRefFunction{}
+}
+
+// secretRefFunction builds a canonical reference to a secret.
+type secretRefFunction struct{}
+
+// secretRef is the parsed form of a secret reference.
+type secretRef struct {
+	OrganizationId string
+	ProjectId      string
+	Key            string
+}
+
+func (f *secretRefFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "secret_ref"
+}
+
+func (f *secretRefFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Build a secret reference",
+		Description: "Builds the canonical `bitwarden://<organization_id>/<project_id>/<key>` reference to a secret, the key being path escaped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "organization_id",
+				Description: "id of the organization the secret belongs to",
+			},
+			function.StringParameter{
+				Name:        "project_id",
+				Description: "id of the project the secret belongs to",
+			},
+			function.StringParameter{
+				Name:        "key",
+				Description: "key/name of the secret",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *secretRefFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var organizationId, projectId, key string
+
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &organizationId, &projectId, &key))
+	if response.Error != nil {
+		return
+	}
+
+	for argument, value := range []string{organizationId, projectId, key} {
+		if value == "" {
+			response.Error = function.NewArgumentFuncError(int64(argument), "The value must not be empty.")
+			return
+		}
+	}
+	for argument, value := range []string{organizationId, projectId} {
+		if strings.Contains(value, "/") {
+			response.Error = function.NewArgumentFuncError(int64(argument), "The value must not contain a slash.")
+			return
+		}
+	}
+
+	ref := formatSecretRef(secretRef{OrganizationId: organizationId, ProjectId: projectId, Key: key})
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, ref))
+}
+
+// formatSecretRef renders the canonical form of a secret reference.
+func formatSecretRef(ref secretRef) string {
+	return secretRefScheme + ref.OrganizationId + "/" + ref.ProjectId + "/" + url.PathEscape(ref.Key)
+}
+
+// parseSecretRef splits a canonical secret reference back into its parts.
+func parseSecretRef(ref string) (secretRef, error) {
+	if !strings.HasPrefix(ref, secretRefScheme) {
+		return secretRef{}, fmt.Errorf("the reference %q does not start with %s", ref, secretRefScheme)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(ref, secretRefScheme), "/")
+	if len(parts) != 3 {
+		return secretRef{}, fmt.Errorf("the reference %q is not in the bitwarden://<organization_id>/<project_id>/<key> format", ref)
+	}
+
+	key, err := url.PathUnescape(parts[2])
+	if err != nil {
+		return secretRef{}, fmt.Errorf("the key of the reference %q is not correctly escaped: %s", ref, err)
+	}
+
+	parsed := secretRef{OrganizationId: parts[0], ProjectId: parts[1], Key: key}
+	if parsed.OrganizationId == "" || parsed.ProjectId == "" || parsed.Key == "" {
+		return secretRef{}, fmt.Errorf("the reference %q has an empty organization id, project id or key", ref)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretRefFunctions(t *testing.T) {
+	ctx := context.Background()
+
+	refResponse := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewSecretRefFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("org"),
+			types.StringValue("project"),
+			types.StringValue("db/password"),
+		}),
+	}, &refResponse)
+	if refResponse.Error != nil {
+		t.Fatalf("err: %s", refResponse.Error)
+	}
+
+	ref := refResponse.Result.Value().(types.String).ValueString()
+	if ref != "bitwarden://org/project/db%2Fpassword" {
+		t.Fatalf("unexpected reference %q", ref)
+	}
+
+	parseResponse := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(secretRefAttributeTypes))}
+	NewParseSecretRefFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(ref)}),
+	}, &parseResponse)
+	if parseResponse.Error != nil {
+		t.Fatalf("err: %s", parseResponse.Error)
+	}
+
+	attributes := parseResponse.Result.Value().(types.Object).Attributes()
+	if attributes["organization_id"].(types.String).ValueString() != "org" ||
+		attributes["project_id"].(types.String).ValueString() != "project" ||
+		attributes["key"].(types.String).ValueString() != "db/password" {
+		t.Fatalf("unexpected parsed reference: %v", attributes)
+	}
+}
+
+func TestParseSecretRefInvalid(t *testing.T) {
+	for _, ref := range []string{
+		"",
+		"org/project/key",
+		"bitwarden://org/project",
+		"bitwarden://org//key",
+		"bitwarden://org/project/key/extra",
+		"bitwarden://org/project/%zz",
+	} {
+		if _, err := parseSecretRef(ref); err == nil {
+			t.Errorf("expected an error for %q", ref)
+		}
+	}
+}
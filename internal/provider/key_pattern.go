@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"regexp"
+)
+
+// keyPatternEnvVarSafe is the preset for keys usable as environment variables,
+// as injected by `bws run`.
+const keyPatternEnvVarSafe = "env_var_safe"
+
+// keyPatternPresets maps the preset names accepted by key_pattern to their regular expression.
+var keyPatternPresets = map[string]string{
+	keyPatternEnvVarSafe: `^[A-Z_][A-Z0-9_]*$`,
+}
+
+// compileKeyPattern compiles a key_pattern value, which is either a preset
+// name or a regular expression.
+func compileKeyPattern(pattern string) (*regexp.Regexp, error) {
+	if preset, ok := keyPatternPresets[pattern]; ok {
+		pattern = preset
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// keyPatternValidator checks that a key_pattern value can be compiled.
+type keyPatternValidator struct{}
+
+var _ validator.String = keyPatternValidator{}
+
+func (v keyPatternValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a regular expression or the %q preset", keyPatternEnvVarSafe)
+}
+
+func (v keyPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v keyPatternValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := compileKeyPattern(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid key pattern",
+			fmt.Sprintf("The key pattern must be a valid regular expression or the %q preset: %s.", keyPatternEnvVarSafe, err),
+		)
+	}
+}
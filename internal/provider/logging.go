@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"time"
+)
+
+// Logging subsystems, which can be filtered with TF_LOG_PROVIDER_<NAME>.
+const (
+	logSubsystemClient  = "bitwarden.client"
+	logSubsystemSecret  = "bitwarden.secret"
+	logSubsystemProject = "bitwarden.project"
+)
+
+// sensitiveLogFields are the log fields whose values are always masked.
+var sensitiveLogFields = []string{"value", "note", "access_token"}
+
+// accessTokenLogPattern matches machine account access tokens wherever they
+// show up in a log message or field, in an SDK error for instance.
+var accessTokenLogPattern = regexp.MustCompile(`0\.[0-9A-Fa-f-]{36}\.[^\s:"]+:[A-Za-z0-9+/=]+`)
+
+// maskLogs masks the secret values, notes and access tokens logged by the
+// root provider logger.
+func maskLogs(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogFields...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, accessTokenLogPattern)
+	return tflog.MaskMessageRegexes(ctx, accessTokenLogPattern)
+}
+
+// newLogSubsystem sets up a logging subsystem in ctx, masking the same
+// values as maskLogs.
+func newLogSubsystem(ctx context.Context, subsystem string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, subsystem)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, sensitiveLogFields...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, subsystem, accessTokenLogPattern)
+	return tflog.SubsystemMaskMessageRegexes(ctx, subsystem, accessTokenLogPattern)
+}
+
+// logOperation logs the outcome of a resource operation.
+func logOperation(ctx context.Context, subsystem string, resourceType string, operation string, start time.Time, ids []string, diags diag.Diagnostics) {
+	fields := map[string]any{
+		"resource_type": resourceType,
+		"operation":     operation,
+		"ids":           ids,
+		"duration_ms":   time.Since(start).Milliseconds(),
+	}
+
+	if diags.HasError() {
+		fields["errors"] = diags.ErrorsCount()
+		tflog.SubsystemWarn(ctx, subsystem, "Bitwarden operation failed", fields)
+		return
+	}
+	tflog.SubsystemDebug(ctx, subsystem, "Bitwarden operation completed", fields)
+}
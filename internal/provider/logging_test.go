@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingMasksSecrets(t *testing.T) {
+	const token = "0.5e3c8b2a-1f4d-4c6e-9a7b-2d8f0e1c3b5a.ClientSecret123:AAECAwQFBgcICQoLDA0ODw=="
+
+	var output bytes.Buffer
+	ctx := maskLogs(tflogtest.RootLogger(context.Background(), &output))
+	tflog.Debug(ctx, "login failed for "+token, map[string]any{"value": "hunter2"})
+
+	ctx = newLogSubsystem(ctx, logSubsystemSecret)
+	tflog.SubsystemDebug(ctx, logSubsystemSecret, "reading", map[string]any{
+		"note":  "private note",
+		"error": "invalid token " + token,
+		"key":   "DATABASE_URL",
+	})
+
+	logs := output.String()
+	for _, leaked := range []string{token, "hunter2", "private note"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("expected %q to be masked, got: %s", leaked, logs)
+		}
+	}
+	if !strings.Contains(logs, "DATABASE_URL") {
+		t.Errorf("expected the key to be logged, got: %s", logs)
+	}
+}
+
+func TestSecretDataSourceSyncFallbackLog(t *testing.T) {
+	const token = "0.5e3c8b2a-1f4d-4c6e-9a7b-2d8f0e1c3b5a.ClientSecret123:AAECAwQFBgcICQoLDA0ODw=="
+
+	fake := newFakeClient()
+	fake.errors = []error{errors.New("invalid token " + token)}
+	dataSource := secretDataSource{client: newTestAPIClient(fake), organizationId: testOrganizationId}
+
+	var output bytes.Buffer
+	if synced := dataSource.syncedSecrets(tflogtest.RootLogger(context.Background(), &output), 2); synced != nil {
+		t.Fatalf("expected the failed sync to fall back, got %v", synced)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, entry := range entries {
+		if entry["@module"] != "provider.bitwarden.client" {
+			t.Errorf("expected the client subsystem, got %v", entry)
+		}
+	}
+	if logs := output.String(); len(entries) == 0 || strings.Contains(logs, token) {
+		t.Errorf("expected the token to be masked, got: %s", logs)
+	}
+}
+
+func TestLogOperation(t *testing.T) {
+	var output bytes.Buffer
+	ctx := newLogSubsystem(tflogtest.RootLogger(context.Background(), &output), logSubsystemProject)
+
+	var diags diag.Diagnostics
+	diags.AddError("summary", "detail")
+	logOperation(ctx, logSubsystemProject, "bitwarden_project", "delete", time.Now(), []string{"id-1", "id-2"}, diags)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one log entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry["@module"] != "provider.bitwarden.project" || entry["operation"] != "delete" || entry["resource_type"] != "bitwarden_project" {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+	if entry["errors"] != float64(1) {
+		t.Errorf("expected the error count, got %v", entry["errors"])
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Errorf("expected the duration, got %v", entry)
+	}
+}
@@ -8,16 +8,22 @@ import (
 	"fmt"
 	bitwarden "github.com/bitwarden/sdk-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"os"
+	"regexp"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+	"time"
 )
 
 var _ provider.Provider = &BitwardenSecretsProvider{}
+var _ provider.ProviderWithFunctions = &BitwardenSecretsProvider{}
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -36,9 +42,35 @@ type BitwardenSecretsProvider struct {
 }
 
 type bitwardenProviderModel struct {
-	ApiUrl      types.String `tfsdk:"api_url"`
-	IdentityUrl types.String `tfsdk:"identity_url"`
-	AccessToken types.String `tfsdk:"access_token"`
+	ApiUrl            types.String  `tfsdk:"api_url"`
+	IdentityUrl       types.String  `tfsdk:"identity_url"`
+	AccessToken       types.String  `tfsdk:"access_token"`
+	OrganizationId    types.String  `tfsdk:"organization_id"`
+	KeyPattern        types.String  `tfsdk:"key_pattern"`
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	MinBackoff        types.String  `tfsdk:"min_backoff"`
+	MaxBackoff        types.String  `tfsdk:"max_backoff"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	PoolSize          types.Int64   `tfsdk:"pool_size"`
+	DisableReadCache  types.Bool    `tfsdk:"disable_read_cache"`
+	Preflight         types.Bool    `tfsdk:"preflight"`
+	ValueChecksumKey  types.String  `tfsdk:"value_checksum_key"`
+}
+
+// bitwardenProviderData is handed to resources and data sources when they are configured.
+type bitwardenProviderData struct {
+	client *apiClient
+	// organizationId is used by resources which don't set their own organization_id.
+	organizationId string
+	// keyPattern is enforced on secret keys, nil when no policy is configured.
+	keyPattern *regexp.Regexp
+	// serviceAccountId is the id of the machine account of the access token.
+	serviceAccountId string
+	// accessibleProjects are the names of the projects the machine account
+	// can see, by id, listed when preflight is enabled and nil otherwise.
+	accessibleProjects map[string]string
+	// valueChecksumKey keys the value_sha256 checksums, nil for plain SHA-256.
+	valueChecksumKey []byte
 }
 
 func (b BitwardenSecretsProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -62,12 +94,69 @@ func (b BitwardenSecretsProvider) Schema(_ context.Context, _ provider.SchemaReq
 				Description: "Password for Bitwarden Secrets Manager API. May also be provided via BITWARDEN_ACCESS_TOKEN environment variable.",
 				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					accessTokenValidator{},
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Description: "Default organization id for resources which don't set their own. May also be provided via BW_ORGANIZATION_ID environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+			"key_pattern": schema.StringAttribute{
+				Description: "Regular expression every secret key must match, or `env_var_safe` for keys usable as environment variables. Secrets can override it with their own key_pattern.",
+				Optional:    true,
+				Validators: []validator.String{
+					keyPatternValidator{},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times a call failing with a rate limit or transient error is retried. Calls which create objects are only retried when the API rejected them before processing. Defaults to 3, 0 disables retries.",
+				Optional:    true,
+			},
+			"min_backoff": schema.StringAttribute{
+				Description: "Delay before the first retry, doubled on every following retry. Defaults to 1s.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				Description: "Maximum delay between two retries. Defaults to 30s.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of API calls per second, shared by every resource and data source of the provider. Unlimited by default.",
+				Optional:    true,
+			},
+			"pool_size": schema.Int64Attribute{
+				Description: "Number of Bitwarden clients calls are spread over. Each client runs one call at a time. Defaults to 1, which serialises every call.",
+				Optional:    true,
+			},
+			"disable_read_cache": schema.BoolAttribute{
+				Description: "Disable the cache which shares, for 30 seconds, the responses of identical reads made during a run. Any write empties the cache.",
+				Optional:    true,
+			},
+			"preflight": schema.BoolAttribute{
+				Description: "List the projects of the organization the machine account can see when the provider is configured, and warn at plan time about secrets of the organization assigned to any other project. Secrets of other organizations are not checked, and projects the machine account can only read are not detected. Requires organization_id.",
+				Optional:    true,
+			},
+			"value_checksum_key": schema.StringAttribute{
+				Description: "Key of the HMAC-SHA256 used for the value_sha256 checksums of secrets, which are plain SHA-256 checksums otherwise. May also be provided via BW_VALUE_CHECKSUM_KEY environment variable.",
+				Optional:    true,
+				Sensitive:   true,
 			},
 		},
 	}
 }
 
 func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
+	ctx = maskLogs(ctx)
 	tflog.Info(ctx, "Configuring Bitwarden client")
 
 	var config bitwardenProviderModel
@@ -102,6 +191,30 @@ func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provide
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the BW_ACCESS_TOKEN environment variable.",
 		)
 	}
+	if config.OrganizationId.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Unknown Bitwarden organization id",
+			"The provider cannot create the Bitwarden client as there is an unknown configuration value for the Bitwarden organization id. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BW_ORGANIZATION_ID environment variable.",
+		)
+	}
+	if config.KeyPattern.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("key_pattern"),
+			"Unknown Bitwarden key pattern",
+			"The provider cannot create the Bitwarden client as there is an unknown configuration value for the secret key pattern. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if config.ValueChecksumKey.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("value_checksum_key"),
+			"Unknown Bitwarden value checksum key",
+			"The provider cannot create the Bitwarden client as there is an unknown configuration value for the value checksum key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BW_VALUE_CHECKSUM_KEY environment variable.",
+		)
+	}
 
 	if response.Diagnostics.HasError() {
 		return
@@ -113,6 +226,8 @@ func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provide
 	apiUrl := os.Getenv("BW_API_URL")
 	identityUrl := os.Getenv("BW_IDENTITY_URL")
 	accessToken := os.Getenv("BW_ACCESS_TOKEN")
+	organizationId := os.Getenv("BW_ORGANIZATION_ID")
+	valueChecksumKey := os.Getenv("BW_VALUE_CHECKSUM_KEY")
 
 	if !config.ApiUrl.IsNull() {
 		apiUrl = config.ApiUrl.ValueString()
@@ -130,6 +245,14 @@ func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provide
 		accessToken = config.AccessToken.ValueString()
 	}
 
+	if !config.OrganizationId.IsNull() {
+		organizationId = config.OrganizationId.ValueString()
+	}
+
+	if !config.ValueChecksumKey.IsNull() {
+		valueChecksumKey = config.ValueChecksumKey.ValueString()
+	}
+
 	if accessToken == "" {
 		response.Diagnostics.AddAttributeError(
 			path.Root("access_token"),
@@ -138,12 +261,95 @@ func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provide
 				"Set the access token in the configuration or use the BW_ACCESS_TOKEN environment variable.",
 		)
 	}
+	token, err := parseAccessToken(accessToken)
+	if accessToken != "" && err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("access_token"),
+			"Invalid Bitwarden access token",
+			accessTokenErrorDetail(err),
+		)
+	}
+
+	if config.Preflight.ValueBool() && organizationId == "" {
+		response.Diagnostics.AddAttributeError(
+			path.Root("preflight"),
+			"Missing organization id for preflight",
+			"The preflight lists the projects of the provider organization. Set organization_id or the BW_ORGANIZATION_ID environment variable.",
+		)
+	}
+
+	var keyPattern *regexp.Regexp
+	if !config.KeyPattern.IsNull() {
+		var err error
+		keyPattern, err = compileKeyPattern(config.KeyPattern.ValueString())
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("key_pattern"),
+				"Invalid key pattern",
+				"The key pattern must be a valid regular expression or the env_var_safe preset: "+err.Error(),
+			)
+		}
+	}
+
+	options := clientOptions{
+		maxRetries:   defaultMaxRetries,
+		minBackoff:   defaultMinBackoff,
+		maxBackoff:   defaultMaxBackoff,
+		readCacheTTL: defaultReadCacheTTL,
+	}
+	if !config.MaxRetries.IsNull() {
+		options.maxRetries = int(config.MaxRetries.ValueInt64())
+		if options.maxRetries < 0 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid maximum number of retries",
+				"The maximum number of retries must be 0 or more.",
+			)
+		}
+	}
+	if !config.MinBackoff.IsNull() {
+		options.minBackoff, _ = time.ParseDuration(config.MinBackoff.ValueString())
+	}
+	if !config.MaxBackoff.IsNull() {
+		options.maxBackoff, _ = time.ParseDuration(config.MaxBackoff.ValueString())
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		options.requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+		if options.requestsPerSecond <= 0 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid request rate",
+				"The number of requests per second must be greater than 0, remove the argument to disable the limit.",
+			)
+		}
+	}
+	if config.DisableReadCache.ValueBool() {
+		options.readCacheTTL = 0
+	}
+	poolSize := 1
+	if !config.PoolSize.IsNull() {
+		poolSize = int(config.PoolSize.ValueInt64())
+		if poolSize < 1 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("pool_size"),
+				"Invalid client pool size",
+				"The client pool size must be 1 or more.",
+			)
+		}
+	}
+	if options.minBackoff > options.maxBackoff {
+		response.Diagnostics.AddAttributeError(
+			path.Root("min_backoff"),
+			"Invalid retry backoff",
+			fmt.Sprintf("The minimum backoff (%s) must not be greater than the maximum backoff (%s).", options.minBackoff, options.maxBackoff),
+		)
+	}
 
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	if apiUrl != "https://api.bitwarden.com" || apiUrl != "https://api.bitwarden.eu" {
+	if apiUrl != "https://api.bitwarden.com" && apiUrl != "https://api.bitwarden.eu" {
 		// as specified in the bitwarden doc https://bitwarden.com/help/public-api/#endpoints
 		apiUrl = fmt.Sprintf("%s/api", apiUrl)
 	}
@@ -157,37 +363,61 @@ func (b BitwardenSecretsProvider) Configure(ctx context.Context, request provide
 
 	ctx = tflog.SetField(ctx, "bw_api_url", apiUrl)
 	ctx = tflog.SetField(ctx, "bw_identity_url", identityUrl)
-	ctx = tflog.SetField(ctx, "bw_access_token", accessToken)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "bw_access_token")
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, accessToken)
+	ctx = tflog.MaskMessageStrings(ctx, accessToken)
 
-	tflog.Debug(ctx, "Creating HashiCups client")
-	bitwardenClient, err := bitwarden.NewBitwardenClient(&apiUrl, &identityUrl)
+	tflog.Debug(ctx, "Creating Bitwarden client", map[string]any{"pool_size": poolSize})
+	sdkClients := make([]bitwarden.BitwardenClientInterface, 0, poolSize)
+	for len(sdkClients) < poolSize {
+		sdkClient, err := bitwarden.NewBitwardenClient(&apiUrl, &identityUrl)
 
-	if err != nil {
-		response.Diagnostics.AddError(
-			"Error while creating the bitwarden client",
-			"validate the api and identity url are correct: "+err.Error(),
-		)
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Error while creating the bitwarden client",
+				"validate the api and identity url are correct: "+err.Error(),
+			)
+			return
+		}
+		sdkClients = append(sdkClients, sdkClient)
 	}
 
-	err = bitwardenClient.AccessTokenLogin(accessToken, nil)
+	bitwardenClient := newAPIClient(sdkClients, options)
+	err = bitwardenClient.AccessTokenLogin(ctx, accessToken)
 
 	if err != nil {
-		response.Diagnostics.AddError(
-			"Unable to login to Bitwarden Secrets Manager",
-			"Either the access token is not valid or there is some communication issues: "+err.Error(),
-		)
+		addAPIError(&response.Diagnostics, "log in to Bitwarden Secrets Manager", err)
+		return
 	}
 
-	response.DataSourceData = bitwardenClient
-	response.ResourceData = bitwardenClient
+	providerData := &bitwardenProviderData{
+		client:           bitwardenClient,
+		organizationId:   organizationId,
+		keyPattern:       keyPattern,
+		serviceAccountId: token.ServiceAccountId,
+	}
+	if valueChecksumKey != "" {
+		providerData.valueChecksumKey = []byte(valueChecksumKey)
+	}
+
+	if config.Preflight.ValueBool() {
+		providerData.accessibleProjects, err = accessibleProjects(ctx, bitwardenClient, organizationId)
+		if err != nil {
+			addAPIError(&response.Diagnostics, "list the projects accessible to the machine account", err)
+			return
+		}
+		tflog.Info(ctx, "Listed the projects accessible to the machine account", map[string]any{"projects": len(providerData.accessibleProjects)})
+	}
+	response.DataSourceData = providerData
+	response.ResourceData = providerData
 
 	tflog.Info(ctx, "Configured bitwarden client", map[string]any{"success": true})
 }
 
 func (b BitwardenSecretsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAccessDataSource,
 		NewProjectDataSource,
+		NewProjectSecretsExportDataSource,
 		NewSecretDataSource,
 	}
 }
@@ -195,7 +425,30 @@ func (b BitwardenSecretsProvider) DataSources(ctx context.Context) []func() data
 func (b BitwardenSecretsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	// Resources defines the resources implemented in the provider.
 	return []func() resource.Resource{
+		NewProjectResource,
 		NewSecretResource,
-		NewSecretResource,
 	}
 }
+
+func (b BitwardenSecretsProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewAccessTokenInfoFunction,
+		NewParseSecretRefFunction,
+		NewSecretRefFunction,
+	}
+}
+
+// accessibleProjects returns the names of the projects of an organization the
+// machine account can see, by id.
+func accessibleProjects(ctx context.Context, client *apiClient, organizationId string) (map[string]string, error) {
+	projects, err := client.Projects(ctx).List(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(projects.Data))
+	for _, project := range projects.Data {
+		names[project.ID] = project.Name
+	}
+	return names, nil
+}
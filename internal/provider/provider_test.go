@@ -4,24 +4,156 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
-// The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"scaffolding": func() (*schema.Provider, error) {
-		return New("dev")(), nil
-	},
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"bitwarden": providerserver.NewProtocol6WithError(New("test")()),
 }
 
 func TestProvider(t *testing.T) {
-	if err := New("dev")().InternalValidate(); err != nil {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
+
+	response, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, diagnostic := range response.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected schema error: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
+
+func TestProviderConfigureInvalidAccessToken(t *testing.T) {
+	t.Setenv("BW_ACCESS_TOKEN", "")
+
+	response := testProviderConfigure(t, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, "1.not-a-uuid.secret:key"),
+	})
+
+	if !response.Diagnostics.HasError() || response.Diagnostics[0].Summary() != "Invalid Bitwarden access token" {
+		t.Fatalf("expected an invalid access token diagnostic, got: %v", response.Diagnostics)
+	}
+	if response.ResourceData != nil {
+		t.Fatal("expected no client to be configured")
+	}
+}
+
+// testProviderConfigure configures the provider with the given attribute
+// values, every other attribute being null.
+func testProviderConfigure(t *testing.T, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	bitwardenProvider := New("test")()
+	schemaResponse := &provider.SchemaResponse{}
+	bitwardenProvider.Schema(ctx, provider.SchemaRequest{}, schemaResponse)
+
+	objectType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	response := &provider.ConfigureResponse{}
+	bitwardenProvider.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResponse.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+	}, response)
+
+	return response
+}
+
+// testResourceSchema returns the schema of a resource.
+func testResourceSchema(t *testing.T, r resource.Resource) resourceschema.Schema {
+	t.Helper()
+
+	response := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", response.Diagnostics)
+	}
+
+	return response.Schema
+}
+
+// testNullTimeouts is an unset timeouts block, for resource models built by tests.
+var testNullTimeouts = timeouts.Value{
+	Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}),
+}
+
+// testReadTimeouts is a timeouts block setting only the read timeout.
+func testReadTimeouts(read string) timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectValueMust(testNullTimeouts.Object.AttributeTypes(context.Background()), map[string]attr.Value{
+			"create": types.StringNull(),
+			"read":   types.StringValue(read),
+			"update": types.StringNull(),
+			"delete": types.StringNull(),
+		}),
+	}
+}
+
+// testResourceValue converts a resource model to its Terraform value.
+func testResourceValue(t *testing.T, r resource.Resource, model any) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	resourceSchema := testResourceSchema(t, r)
+	state := tfsdk.State{
+		Schema: resourceSchema,
+		Raw:    tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	return state.Raw
+}
+
+// testResourceState builds the state of a resource from its model.
+func testResourceState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	return tfsdk.State{Schema: testResourceSchema(t, r), Raw: testResourceValue(t, r, model)}
+}
+
+// testResourceConfig builds the configuration of a resource from its model.
+func testResourceConfig(t *testing.T, r resource.Resource, model any) tfsdk.Config {
+	t.Helper()
+
+	return tfsdk.Config{Schema: testResourceSchema(t, r), Raw: testResourceValue(t, r, model)}
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -8,18 +8,26 @@ import (
 	"fmt"
 	bitwarden "github.com/bitwarden/sdk-go"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"sort"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithValidateConfig = &ProjectResource{}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
@@ -27,13 +35,20 @@ func NewProjectResource() resource.Resource {
 
 // ProjectResource defines the resource implementation.
 type ProjectResource struct {
-	client bitwarden.BitwardenClientInterface
+	client *apiClient
+	// organizationId is the provider default organization.
+	organizationId string
 }
 
 // ProjectResourceModel describes the resource data model.
 type ProjectResourceModel struct {
-	Projects []projectItemModel `tfsdk:"projects"`
-	Id       types.String       `tfsdk:"id"`
+	Projects           []projectItemModel `tfsdk:"projects"`
+	DeletionProtection types.Bool         `tfsdk:"deletion_protection"`
+	OnDestroy          types.String       `tfsdk:"on_destroy"`
+	ForceDestroy       types.Bool         `tfsdk:"force_destroy"`
+	AdoptExisting      types.Bool         `tfsdk:"adopt_existing"`
+	Id                 types.String       `tfsdk:"id"`
+	Timeouts           timeouts.Value     `tfsdk:"timeouts"`
 }
 
 type projectItemModel struct {
@@ -52,18 +67,45 @@ func (r *ProjectResource) Schema(ctx context.Context, request resource.SchemaReq
 		MarkdownDescription: "Project Resource",
 
 		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: "name of the project",
-				Optional:            true,
-			},
-			"project_id": schema.StringAttribute{
-				MarkdownDescription: "id of the project in bitwarden secrets manager",
-				Computed:            true,
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "projects managed by this resource",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "name of the project",
+							Required:            true,
+						},
+						"project_id": schema.StringAttribute{
+							MarkdownDescription: "id of the project in bitwarden secrets manager",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"organization_id": schema.StringAttribute{
+							MarkdownDescription: "id of the organization associated with the project, defaults to the provider organization_id",
+							Optional:            true,
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+							Validators: []validator.String{
+								validators.UUID(),
+							},
+						},
+					},
+				},
 			},
-			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "id of the organization associated with the project",
+			"deletion_protection": deletionProtectionAttribute("projects"),
+			"on_destroy":          onDestroyAttribute("projects"),
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "delete the projects even when secrets are still assigned to them, otherwise the deletion is refused",
+				Optional:            true,
 				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
+			"adopt_existing": adoptExistingAttribute("projects", "name in the same organization"),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Example identifier",
@@ -72,6 +114,9 @@ func (r *ProjectResource) Schema(ctx context.Context, request resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": operationTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -81,18 +126,33 @@ func (r *ProjectResource) Configure(ctx context.Context, request resource.Config
 		return
 	}
 
-	client, ok := request.ProviderData.(bitwarden.BitwardenClientInterface)
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected bitwarden.BitwardenClientInterface, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.client
+	r.organizationId = providerData.organizationId
+}
+
+// ValidateConfig catches blank, too long and duplicate project names during
+// `terraform validate`.
+func (r *ProjectResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data ProjectResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	validateProjectItems(data.Projects, &response.Diagnostics)
 }
 
 func (r *ProjectResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -105,6 +165,21 @@ func (r *ProjectResource) Create(ctx context.Context, request resource.CreateReq
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemProject)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemProject, "bitwarden_project", "create", start, data.projectIds(), response.Diagnostics)
+	}()
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -124,16 +199,69 @@ func (r *ProjectResource) Create(ctx context.Context, request resource.CreateReq
 	}
 	data.Id = types.StringValue(resourceId)
 
+	for projectIndex, project := range data.Projects {
+		if r.projectOrganizationId(project) == "" {
+			response.Diagnostics.AddAttributeError(
+				path.Root("projects").AtListIndex(projectIndex).AtName("organization_id"),
+				"Missing organization id",
+				"Set organization_id on the project or on the provider.",
+			)
+		}
+	}
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Projects to adopt, indexed by organization and name.
+	var existing map[string]map[string][]bitwarden.ProjectResponse
+	if data.AdoptExisting.ValueBool() {
+		existing = make(map[string]map[string][]bitwarden.ProjectResponse)
+		for _, project := range data.Projects {
+			organizationId := r.projectOrganizationId(project)
+			if _, ok := existing[organizationId]; ok {
+				continue
+			}
+
+			existing[organizationId], err = existingProjects(ctx, r.client, organizationId)
+			if err != nil {
+				addAPIError(&response.Diagnostics, "list existing projects", err)
+				return
+			}
+		}
+	}
+
 	var projectsCreation []*bitwarden.ProjectResponse
-	for _, project := range data.Projects {
-		projectCreation, err := r.client.Projects().Create(project.Name.ValueString(), project.OrganizationId.ValueString())
-		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating project",
-				"Could not create project, unexpected error: "+err.Error(),
+	for projectIndex, project := range data.Projects {
+		projectPath := path.Root("projects").AtListIndex(projectIndex)
+
+		// Only the name and organization of a project can be configured, so
+		// an adopted project already matches the configuration.
+		switch adoptable := existing[r.projectOrganizationId(project)][project.Name.ValueString()]; len(adoptable) {
+		case 0:
+		case 1:
+			tflog.SubsystemInfo(ctx, logSubsystemProject, "adopting existing project", map[string]any{"name": project.Name.ValueString(), "project_id": adoptable[0].ID})
+			projectsCreation = append(projectsCreation, &adoptable[0])
+			continue
+		default:
+			var projectIds []string
+			for _, adoptableProject := range adoptable {
+				projectIds = append(projectIds, adoptableProject.ID)
+			}
+			response.Diagnostics.AddAttributeError(
+				projectPath.AtName("name"),
+				"Project name is used several times",
+				fmt.Sprintf("The name %q is used by %d projects of the organization (%s), which one to adopt is ambiguous. Import the project instead.",
+					project.Name.ValueString(), len(adoptable), strings.Join(projectIds, ", ")),
 			)
 			return
 		}
+
+		projectCreation, err := r.client.Projects(ctx).Create(r.projectOrganizationId(project), project.Name.ValueString())
+		if err != nil {
+			addAttributeAPIError(&response.Diagnostics, projectPath, "create project", err)
+			return
+		}
 		projectsCreation = append(projectsCreation, projectCreation)
 	}
 
@@ -150,7 +278,7 @@ func (r *ProjectResource) Create(ctx context.Context, request resource.CreateReq
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	tflog.SubsystemTrace(ctx, logSubsystemProject, "created a resource")
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
@@ -166,6 +294,21 @@ func (r *ProjectResource) Read(ctx context.Context, request resource.ReadRequest
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemProject)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemProject, "bitwarden_project", "read", start, data.projectIds(), response.Diagnostics)
+	}()
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -175,13 +318,10 @@ func (r *ProjectResource) Read(ctx context.Context, request resource.ReadRequest
 	// }
 
 	var projects []*bitwarden.ProjectResponse
-	for _, project := range data.Projects {
-		project, err := r.client.Projects().Get(project.ProjectId.ValueString())
+	for projectIndex, project := range data.Projects {
+		project, err := r.client.Projects(ctx).Get(project.ProjectId.ValueString())
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating project",
-				"Could not find project, unexpected error: "+err.Error(),
-			)
+			addAttributeAPIError(&response.Diagnostics, path.Root("projects").AtListIndex(projectIndex), "read project", err)
 			return
 		}
 		projects = append(projects, project)
@@ -209,6 +349,21 @@ func (r *ProjectResource) Update(ctx context.Context, request resource.UpdateReq
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemProject)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemProject, "bitwarden_project", "update", start, data.projectIds(), response.Diagnostics)
+	}()
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -218,17 +373,14 @@ func (r *ProjectResource) Update(ctx context.Context, request resource.UpdateReq
 	// }
 
 	var projects []*bitwarden.ProjectResponse
-	for _, project := range data.Projects {
-		project, err := r.client.Projects().Update(
+	for projectIndex, project := range data.Projects {
+		project, err := r.client.Projects(ctx).Update(
 			project.ProjectId.ValueString(),
-			project.OrganizationId.ValueString(),
+			r.projectOrganizationId(project),
 			project.Name.ValueString(),
 		)
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating project",
-				"Could not update project, unexpected error: "+err.Error(),
-			)
+			addAttributeAPIError(&response.Diagnostics, path.Root("projects").AtListIndex(projectIndex), "update project", err)
 			return
 		}
 		projects = append(projects, project)
@@ -259,6 +411,21 @@ func (r *ProjectResource) Delete(ctx context.Context, request resource.DeleteReq
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemProject)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemProject, "bitwarden_project", "delete", start, data.projectIds(), response.Diagnostics)
+	}()
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -267,23 +434,135 @@ func (r *ProjectResource) Delete(ctx context.Context, request resource.DeleteReq
 	//     return
 	// }
 
+	if data.OnDestroy.ValueString() == onDestroyAbandon {
+		tflog.SubsystemInfo(ctx, logSubsystemProject, "abandoning projects, they are only removed from the state")
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		response.Diagnostics.AddError(
+			"Projects are protected from deletion",
+			"deletion_protection is enabled on this resource. Set deletion_protection to false and apply before destroying it, "+
+				"or set on_destroy to \"abandon\" to only remove it from the Terraform state.",
+		)
+		return
+	}
+
+	if !data.ForceDestroy.ValueBool() {
+		projectSecrets, err := r.projectSecretKeys(ctx, data.Projects)
+		if err != nil {
+			addAPIError(&response.Diagnostics, "check that the projects are empty", err)
+			return
+		}
+
+		for projectIndex, project := range data.Projects {
+			keys := projectSecrets[project.ProjectId.ValueString()]
+			if len(keys) == 0 {
+				continue
+			}
+
+			response.Diagnostics.AddAttributeError(
+				path.Root("projects").AtListIndex(projectIndex),
+				"Project still contains secrets",
+				fmt.Sprintf("The project %q still contains %d secret(s): %s. Move or delete them first, "+
+					"or set force_destroy to true to delete the project anyway.",
+					project.Name.ValueString(), len(keys), strings.Join(keys, ", ")),
+			)
+		}
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	var projectsToDelete []string
 
 	for _, project := range data.Projects {
 		projectsToDelete = append(projectsToDelete, project.ProjectId.ValueString())
 	}
 
-	_, err := r.client.Projects().Delete(projectsToDelete)
+	if len(projectsToDelete) == 0 {
+		return
+	}
+
+	deletion, err := r.client.Projects(ctx).Delete(projectsToDelete)
 	if err != nil {
-		response.Diagnostics.AddError(
-			"Error creating project",
-			"Could not delete project, unexpected error: "+err.Error(),
-		)
+		addAPIError(&response.Diagnostics, "delete projects", err)
 		return
 	}
 
+	var results []deleteItemResult
+	for _, item := range deletion.Data {
+		results = append(results, deleteItemResult{id: item.ID, err: item.Error})
+	}
+
+	failures := deleteItemErrors(results)
+	for projectIndex, project := range data.Projects {
+		if message, ok := failures[project.ProjectId.ValueString()]; ok {
+			response.Diagnostics.AddAttributeError(
+				path.Root("projects").AtListIndex(projectIndex),
+				"Unable to delete project",
+				fmt.Sprintf("The project %q (%s) could not be deleted: %s", project.Name.ValueString(), project.ProjectId.ValueString(), message),
+			)
+		}
+	}
+
 }
 
 func (r *ProjectResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
 }
+
+// projectOrganizationId returns the organization of a project, falling back to
+// the provider organization when the project doesn't set one.
+func (r *ProjectResource) projectOrganizationId(project projectItemModel) string {
+	if project.OrganizationId.IsNull() || project.OrganizationId.IsUnknown() {
+		return r.organizationId
+	}
+	return project.OrganizationId.ValueString()
+}
+
+// projectSecretKeys returns the keys of the secrets assigned to each of the
+// projects, indexed by project id. It runs a full sync rather than reusing the
+// synced snapshot, which may miss secrets created since it was taken.
+func (r *ProjectResource) projectSecretKeys(ctx context.Context, projects []projectItemModel) (map[string][]string, error) {
+	projectIds := make(map[string]bool, len(projects))
+	organizationIds := make(map[string]bool)
+	for _, project := range projects {
+		projectIds[project.ProjectId.ValueString()] = true
+		organizationIds[r.projectOrganizationId(project)] = true
+	}
+
+	keys := make(map[string][]string)
+	for organizationId := range organizationIds {
+		secrets, err := r.client.Secrets(ctx).Sync(organizationId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, secret := range secrets.Secrets {
+			if secret.ProjectID != nil && projectIds[*secret.ProjectID] {
+				keys[*secret.ProjectID] = append(keys[*secret.ProjectID], secret.Key)
+			}
+		}
+	}
+
+	for _, projectKeys := range keys {
+		sort.Strings(projectKeys)
+	}
+
+	return keys, nil
+}
+
+// projectIds returns the ids of the projects, nil when the model couldn't be read.
+func (m *ProjectResourceModel) projectIds() []string {
+	if m == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(m.Projects))
+	for _, item := range m.Projects {
+		ids = append(ids, item.ProjectId.ValueString())
+	}
+	return ids
+}
@@ -4,9 +4,16 @@
 package provider
 
 import (
+	"context"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -14,8 +21,8 @@ func TestAccResourceScaffolding(t *testing.T) {
 	t.Skip("resource not yet implemented, remove this once you add your own code")
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceScaffolding,
@@ -33,3 +40,171 @@ resource "scaffolding_resource" "foo" {
   sample_attribute = "bar"
 }
 `
+
+func TestProjectResourceDeleteProtection(t *testing.T) {
+	client := newFakeClient()
+	project := client.addProject(testOrganizationId, "shared")
+
+	projectResource := &ProjectResource{client: newTestAPIClient(client)}
+	state := ProjectResourceModel{
+		Timeouts:           testNullTimeouts,
+		Id:                 types.StringValue("resource"),
+		DeletionProtection: types.BoolValue(true),
+		OnDestroy:          types.StringValue(onDestroyDelete),
+		Projects: []projectItemModel{
+			{Name: types.StringValue(project.Name), ProjectId: types.StringValue(project.ID), OrganizationId: types.StringValue(testOrganizationId)},
+		},
+	}
+
+	response := &frameworkresource.DeleteResponse{}
+	projectResource.Delete(context.Background(), frameworkresource.DeleteRequest{
+		State: testResourceState(t, projectResource, state),
+	}, response)
+
+	if !response.Diagnostics.HasError() {
+		t.Fatal("expected the protected project deletion to fail")
+	}
+	if _, err := client.Projects().Get(project.ID); err != nil {
+		t.Fatalf("expected the project to be kept: %s", err)
+	}
+}
+
+func TestProjectResourceDeleteNotEmpty(t *testing.T) {
+	for name, forceDestroy := range map[string]bool{"refused": false, "forced": true} {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			project := client.addProject(testOrganizationId, "shared")
+			client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "s3cr3t")
+
+			projectResource := &ProjectResource{client: newTestAPIClient(client)}
+			state := ProjectResourceModel{
+				Timeouts:     testNullTimeouts,
+				Id:           types.StringValue("resource"),
+				OnDestroy:    types.StringValue(onDestroyDelete),
+				ForceDestroy: types.BoolValue(forceDestroy),
+				Projects: []projectItemModel{
+					{Name: types.StringValue(project.Name), ProjectId: types.StringValue(project.ID), OrganizationId: types.StringValue(testOrganizationId)},
+				},
+			}
+
+			response := &frameworkresource.DeleteResponse{}
+			projectResource.Delete(context.Background(), frameworkresource.DeleteRequest{
+				State: testResourceState(t, projectResource, state),
+			}, response)
+
+			if response.Diagnostics.HasError() == forceDestroy {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if !forceDestroy && !strings.Contains(response.Diagnostics[0].Detail(), "DB_PASSWORD") {
+				t.Fatalf("expected the diagnostic to list the secret keys: %s", response.Diagnostics[0].Detail())
+			}
+			if _, err := client.Projects().Get(project.ID); (err != nil) != forceDestroy {
+				t.Fatalf("unexpected remote project state, deleted: %t", err != nil)
+			}
+		})
+	}
+}
+
+func TestProjectResourceDeleteNotEmptyAfterSync(t *testing.T) {
+	client := newFakeClient()
+	project := client.addProject(testOrganizationId, "shared")
+	apiClient := newTestCachedAPIClient(client, time.Minute)
+	if _, err := apiClient.SyncedSecrets(context.Background(), testOrganizationId); err != nil {
+		t.Fatal(err)
+	}
+	client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "s3cr3t")
+
+	projectResource := &ProjectResource{client: apiClient}
+	state := ProjectResourceModel{
+		Timeouts:     testNullTimeouts,
+		Id:           types.StringValue("resource"),
+		OnDestroy:    types.StringValue(onDestroyDelete),
+		ForceDestroy: types.BoolValue(false),
+		Projects: []projectItemModel{
+			{Name: types.StringValue(project.Name), ProjectId: types.StringValue(project.ID), OrganizationId: types.StringValue(testOrganizationId)},
+		},
+	}
+
+	response := &frameworkresource.DeleteResponse{}
+	projectResource.Delete(context.Background(), frameworkresource.DeleteRequest{
+		State: testResourceState(t, projectResource, state),
+	}, response)
+
+	if !response.Diagnostics.HasError() {
+		t.Fatal("expected the deletion of the project holding a secret created after the sync to fail")
+	}
+	if _, err := client.Projects().Get(project.ID); err != nil {
+		t.Fatalf("expected the project to be kept: %s", err)
+	}
+}
+
+func TestProjectResourceCreateAdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		adoptExisting bool
+		existing      int
+		expectErr     bool
+		expectAdopted bool
+		expectCount   int
+	}{
+		"disabled":  {existing: 1, expectCount: 2},
+		"none":      {adoptExisting: true, expectCount: 1},
+		"adopt":     {adoptExisting: true, existing: 1, expectAdopted: true, expectCount: 1},
+		"ambiguous": {adoptExisting: true, existing: 2, expectErr: true, expectCount: 2},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeClient()
+			client.addProject(testOrganizationId, "other")
+
+			var existingIds []string
+			for i := 0; i < testCase.existing; i++ {
+				existingIds = append(existingIds, client.addProject(testOrganizationId, "shared").ID)
+			}
+
+			projectResource := &ProjectResource{client: newTestAPIClient(client), organizationId: testOrganizationId}
+			plan := ProjectResourceModel{
+				Timeouts:      testNullTimeouts,
+				Id:            types.StringUnknown(),
+				AdoptExisting: types.BoolValue(testCase.adoptExisting),
+				Projects: []projectItemModel{
+					{Name: types.StringValue("shared"), ProjectId: types.StringUnknown(), OrganizationId: types.StringUnknown()},
+				},
+			}
+
+			resourceSchema := testResourceSchema(t, projectResource)
+			response := &frameworkresource.CreateResponse{
+				State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+			}
+			projectResource.Create(ctx, frameworkresource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, projectResource, plan)},
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+
+			count := 0
+			for _, project := range client.projects {
+				if project.Name == "shared" {
+					count++
+				}
+			}
+			if count != testCase.expectCount {
+				t.Fatalf("expected %d projects named shared, got %d", testCase.expectCount, count)
+			}
+
+			if testCase.expectErr {
+				return
+			}
+
+			var state ProjectResourceModel
+			response.Diagnostics.Append(response.State.Get(ctx, &state)...)
+			projectId := state.Projects[0].ProjectId.ValueString()
+			if adopted := testCase.existing > 0 && projectId == existingIds[0]; adopted != testCase.expectAdopted {
+				t.Fatalf("unexpected project id %s, adopted: %t", projectId, adopted)
+			}
+		})
+	}
+}
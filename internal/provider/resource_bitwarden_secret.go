@@ -8,18 +8,29 @@ import (
 	"fmt"
 	bitwarden "github.com/bitwarden/sdk-go"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SecretResource{}
 var _ resource.ResourceWithImportState = &SecretResource{}
+var _ resource.ResourceWithValidateConfig = &SecretResource{}
+var _ resource.ResourceWithModifyPlan = &SecretResource{}
 
 func NewSecretResource() resource.Resource {
 	return &SecretResource{}
@@ -27,18 +38,36 @@ func NewSecretResource() resource.Resource {
 
 // SecretResource defines the resource implementation.
 type SecretResource struct {
-	client bitwarden.BitwardenClientInterface
+	client *apiClient
+	// organizationId is the provider default organization.
+	organizationId string
+	// keyPattern is the provider key policy, nil when none is configured.
+	keyPattern *regexp.Regexp
+	// accessibleProjects are the projects listed by the provider preflight,
+	// nil when it is disabled.
+	accessibleProjects map[string]string
+	// valueChecksumKey keys the value_sha256 checksums, nil for plain SHA-256.
+	valueChecksumKey []byte
 }
 
 // SecretResourceModel describes the resource data model.
 type SecretResourceModel struct {
-	Secrets []secretItemModel `tfsdk:"secrets"`
-	Id      types.String      `tfsdk:"id"`
+	Secrets            []secretItemModel `tfsdk:"secrets"`
+	KeyPattern         types.String      `tfsdk:"key_pattern"`
+	DeletionProtection types.Bool        `tfsdk:"deletion_protection"`
+	OnDestroy          types.String      `tfsdk:"on_destroy"`
+	UniqueKeyInProject types.Bool        `tfsdk:"unique_key_in_project"`
+	OnDuplicateKey     types.String      `tfsdk:"on_duplicate_key"`
+	AdoptExisting      types.Bool        `tfsdk:"adopt_existing"`
+	ManageValue        types.Bool        `tfsdk:"manage_value"`
+	Id                 types.String      `tfsdk:"id"`
+	Timeouts           timeouts.Value    `tfsdk:"timeouts"`
 }
 
 type secretItemModel struct {
 	Key            types.String `tfsdk:"key"`
 	Value          types.String `tfsdk:"value"`
+	ValueSha256    types.String `tfsdk:"value_sha256"`
 	Note           types.String `tfsdk:"note"`
 	SecretId       types.String `tfsdk:"secret_id"`
 	ProjectId      types.String `tfsdk:"project_id"`
@@ -55,17 +84,82 @@ func (r *SecretResource) Schema(ctx context.Context, request resource.SchemaRequ
 		MarkdownDescription: "Secret Resource",
 
 		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: "name of the project",
+			"secrets": schema.ListNestedAttribute{
+				MarkdownDescription: "secrets managed by this resource",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "key/name of the secret",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "value of the secret",
+							Required:            true,
+							Sensitive:           true,
+						},
+						"value_sha256": schema.StringAttribute{
+							MarkdownDescription: "SHA-256 checksum of the value, an HMAC-SHA256 when the provider value_checksum_key is set, to trigger changes when the value changes without referencing it",
+							Computed:            true,
+						},
+						"note": schema.StringAttribute{
+							MarkdownDescription: "note for the secret",
+							Optional:            true,
+							Computed:            true,
+							Sensitive:           true,
+							Default:             stringdefault.StaticString(""),
+						},
+						"secret_id": schema.StringAttribute{
+							MarkdownDescription: "id of the secret in bitwarden secrets manager",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"project_id": schema.StringAttribute{
+							MarkdownDescription: "id of the project the secret belongs to",
+							Optional:            true,
+							Validators: []validator.String{
+								validators.UUID(),
+							},
+						},
+						"organization_id": schema.StringAttribute{
+							MarkdownDescription: "id of the organization associated with the secret, defaults to the provider organization_id",
+							Optional:            true,
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+							Validators: []validator.String{
+								validators.UUID(),
+							},
+						},
+					},
+				},
+			},
+			"key_pattern": schema.StringAttribute{
+				MarkdownDescription: "regular expression every key must match, or `env_var_safe` for keys usable as environment variables, overrides the provider key_pattern",
 				Optional:            true,
+				Validators: []validator.String{
+					keyPatternValidator{},
+				},
 			},
-			"secret_id": schema.StringAttribute{
-				MarkdownDescription: "id of the project in bitwarden secrets manager",
+			"deletion_protection": deletionProtectionAttribute("secrets"),
+			"on_destroy":          onDestroyAttribute("secrets"),
+			"unique_key_in_project": schema.BoolAttribute{
+				MarkdownDescription: "check on create whether a secret of the project already uses the key, as duplicate keys break `bws run`, see on_duplicate_key",
+				Optional:            true,
 				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
-			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "id of the organization associated with the project",
-				Computed:            true,
+			"on_duplicate_key": onDuplicateKeyAttribute(),
+			"adopt_existing":   adoptExistingAttribute("secrets", "key in the same project"),
+			"manage_value": schema.BoolAttribute{
+				MarkdownDescription: "when false, the values are only written on create and values rotated outside of Terraform are left alone, " +
+					"while keys, notes and projects are still managed. The state keeps the configured values and value_sha256 follows the remote values",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -75,6 +169,9 @@ func (r *SecretResource) Schema(ctx context.Context, request resource.SchemaRequ
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": operationTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -84,18 +181,190 @@ func (r *SecretResource) Configure(ctx context.Context, request resource.Configu
 		return
 	}
 
-	client, ok := request.ProviderData.(bitwarden.BitwardenClientInterface)
+	providerData, ok := request.ProviderData.(*bitwardenProviderData)
 
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected bitwarden.BitwardenClientInterface, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *bitwardenProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.client
+	r.organizationId = providerData.organizationId
+	r.keyPattern = providerData.keyPattern
+	r.accessibleProjects = providerData.accessibleProjects
+	r.valueChecksumKey = providerData.valueChecksumKey
+}
+
+func (r *SecretResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data SecretResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	validateSecretItems(data.Secrets, &response.Diagnostics)
+
+	// adopt_existing is a shorthand for adopting duplicate keys.
+	if data.AdoptExisting.ValueBool() && !data.OnDuplicateKey.IsNull() && !data.OnDuplicateKey.IsUnknown() &&
+		data.OnDuplicateKey.ValueString() != onDuplicateKeyAdopt {
+		response.Diagnostics.AddAttributeError(
+			path.Root("on_duplicate_key"),
+			"Conflicting duplicate key handling",
+			fmt.Sprintf("adopt_existing adopts the secrets already using a key, which conflicts with on_duplicate_key = %q. Remove one of them.", data.OnDuplicateKey.ValueString()),
+		)
+	}
+
+	// The provider policy is only known once the provider is configured, so
+	// `terraform validate` only enforces the resource key_pattern.
+	keyPattern := r.keyPattern
+	if data.KeyPattern.IsUnknown() {
+		return
+	}
+	if !data.KeyPattern.IsNull() {
+		var err error
+		keyPattern, err = compileKeyPattern(data.KeyPattern.ValueString())
+		if err != nil {
+			// Reported by the attribute validator.
+			return
+		}
+	}
+
+	if keyPattern == nil {
+		return
+	}
+
+	for secretIndex, secret := range data.Secrets {
+		if secret.Key.IsNull() || secret.Key.IsUnknown() {
+			continue
+		}
+
+		if !keyPattern.MatchString(secret.Key.ValueString()) {
+			response.Diagnostics.AddAttributeError(
+				path.Root("secrets").AtListIndex(secretIndex).AtName("key"),
+				"Secret key does not match the key pattern",
+				fmt.Sprintf("The key %q does not match the pattern %s. Rename the secret or adjust key_pattern.", secret.Key.ValueString(), keyPattern),
+			)
+		}
+	}
+}
+
+// ModifyPlan plans the value checksums, which are known as soon as the values
+// are. It also warns when a secret is assigned to a project the machine
+// account cannot see, as the change would only fail when applied. With the
+// provider preflight, the projects of the provider organization are checked
+// against the projects listed when the provider was configured; otherwise
+// only the projects secrets move to are read. The SDK doesn't expose project permissions, so a visible
+// project the machine account can only read is not detected.
+func (r *SecretResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy, nor before the provider is configured.
+	if request.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan SecretResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Unmanaged values are not written, so their checksums follow the remote
+	// values refreshed into the state.
+	var priorSecrets []secretItemModel
+	if !plan.managesValue() && !request.State.Raw.IsNull() {
+		var prior SecretResourceModel
+
+		response.Diagnostics.Append(request.State.Get(ctx, &prior)...)
+
+		if response.Diagnostics.HasError() {
+			return
+		}
+		priorSecrets = prior.Secrets
+	}
+
+	for secretIndex, secret := range plan.Secrets {
+		switch {
+		case plan.managesValue():
+			plan.Secrets[secretIndex].ValueSha256 = valueSha256Value(secret.Value, r.valueChecksumKey)
+		case secretIndex < len(priorSecrets) && !priorSecrets[secretIndex].ValueSha256.IsNull():
+			plan.Secrets[secretIndex].ValueSha256 = priorSecrets[secretIndex].ValueSha256
+		default:
+			plan.Secrets[secretIndex].ValueSha256 = types.StringUnknown()
+		}
+	}
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+
+	if r.accessibleProjects != nil {
+		for secretIndex, secret := range plan.Secrets {
+			// The preflight only listed the projects of the provider
+			// organization.
+			if secret.ProjectId.IsUnknown() || secret.ProjectId.IsNull() || r.secretOrganizationId(secret) != r.organizationId {
+				continue
+			}
+
+			if _, ok := r.accessibleProjects[secret.ProjectId.ValueString()]; !ok {
+				response.Diagnostics.AddAttributeWarning(
+					path.Root("secrets").AtListIndex(secretIndex).AtName("project_id"),
+					"Project is not visible",
+					fmt.Sprintf("The secret %q is assigned to the project %s, which the machine account could not see when the provider was configured. "+
+						"Applying the secret will fail unless the machine account is granted read/write access to the project.",
+						secret.Key.ValueString(), secret.ProjectId.ValueString()),
+				)
+			}
+		}
+		return
+	}
+
+	// Nothing moves on create.
+	if request.State.Raw.IsNull() {
+		return
+	}
+
+	var state SecretResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// The timeouts block doesn't cover planning, so the project reads are
+	// bounded by the read timeout instead of stalling the plan.
+	readTimeout, diags := plan.Timeouts.Read(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	for secretIndex, secret := range plan.Secrets {
+		if secretIndex >= len(state.Secrets) || secret.ProjectId.IsUnknown() || secret.ProjectId.IsNull() {
+			continue
+		}
+
+		if secret.ProjectId.Equal(state.Secrets[secretIndex].ProjectId) {
+			continue
+		}
+
+		if _, err := r.client.Projects(ctx).Get(secret.ProjectId.ValueString()); err != nil {
+			response.Diagnostics.AddAttributeWarning(
+				path.Root("secrets").AtListIndex(secretIndex).AtName("project_id"),
+				"Target project is not visible",
+				fmt.Sprintf("The secret %q moves to the project %s, which the machine account cannot see: %s. "+
+					"The update will fail unless the machine account is granted read/write access to the project.",
+					secret.Key.ValueString(), secret.ProjectId.ValueString(), err.Error()),
+			)
+		}
+	}
 }
 
 func (r *SecretResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -108,6 +377,21 @@ func (r *SecretResource) Create(ctx context.Context, request resource.CreateRequ
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemSecret)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemSecret, "bitwarden_secret", "create", start, data.secretIds(), response.Diagnostics)
+	}()
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -127,33 +411,50 @@ func (r *SecretResource) Create(ctx context.Context, request resource.CreateRequ
 	}
 	data.Id = types.StringValue(resourceId)
 
-	var secretsCreation []*bitwarden.SecretResponse
-	for _, secret := range data.Secrets {
-		SecretCreation, err := r.client.Secrets().Create(
-			secret.Key.ValueString(),
-			secret.Value.ValueString(),
-			secret.Note.ValueString(),
-			secret.OrganizationId.ValueString(),
-			[]string{secret.ProjectId.ValueString()},
-		)
-		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating secret",
-				"Could not create secret, unexpected error: "+err.Error(),
+	for secretIndex, secret := range data.Secrets {
+		if r.secretOrganizationId(secret) == "" {
+			response.Diagnostics.AddAttributeError(
+				path.Root("secrets").AtListIndex(secretIndex).AtName("organization_id"),
+				"Missing organization id",
+				"Set organization_id on the secret or on the provider.",
 			)
+		}
+	}
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	onDuplicateKey := data.OnDuplicateKey.ValueString()
+	if data.AdoptExisting.ValueBool() {
+		onDuplicateKey = onDuplicateKeyAdopt
+	}
+
+	var existing map[secretLocation][]bitwarden.SecretResponse
+	if data.UniqueKeyInProject.ValueBool() || data.AdoptExisting.ValueBool() {
+		existing, err = r.existingSecrets(ctx, data.Secrets)
+		if err != nil {
+			addAPIError(&response.Diagnostics, "list existing secrets", err)
+			return
+		}
+	}
+
+	var secretsCreation []*bitwarden.SecretResponse
+	for secretIndex, secret := range data.Secrets {
+		SecretCreation := r.createSecret(ctx, secret, existing[r.secretLocation(secret)], onDuplicateKey, data.managesValue(),
+			path.Root("secrets").AtListIndex(secretIndex), &response.Diagnostics)
+		if response.Diagnostics.HasError() {
 			return
 		}
 		secretsCreation = append(secretsCreation, SecretCreation)
 	}
 
 	for projectIndex, projectItem := range secretsCreation {
-		data.Secrets[projectIndex] = secretItemModel{
-			Key:            types.StringValue(projectItem.Key),
-			Value:          types.StringValue(projectItem.Value),
-			Note:           types.StringValue(projectItem.Note),
-			OrganizationId: types.StringValue(projectItem.OrganizationID),
-			SecretId:       types.StringValue(projectItem.ID),
+		item := newSecretItemModel(projectItem, r.valueChecksumKey)
+		if !data.managesValue() {
+			item = unmanagedSecretItem(item, data.Secrets[projectIndex])
 		}
+		data.Secrets[projectIndex] = item
 	}
 
 	// For the purposes of this example code, hardcoding a response value to
@@ -161,7 +462,7 @@ func (r *SecretResource) Create(ctx context.Context, request resource.CreateRequ
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	tflog.SubsystemTrace(ctx, logSubsystemSecret, "created a resource")
 
 	// Save data into Terraform state
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
@@ -177,6 +478,21 @@ func (r *SecretResource) Read(ctx context.Context, request resource.ReadRequest,
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemSecret)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemSecret, "bitwarden_secret", "read", start, data.secretIds(), response.Diagnostics)
+	}()
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -185,27 +501,25 @@ func (r *SecretResource) Read(ctx context.Context, request resource.ReadRequest,
 	//     return
 	// }
 
-	var secrets []*bitwarden.SecretResponse
+	var secretIds []string
 	for _, secret := range data.Secrets {
-		secret, err := r.client.Secrets().Get(secret.SecretId.ValueString())
-		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating secret",
-				"Could not find secret, unexpected error: "+err.Error(),
-			)
-			return
-		}
-		secrets = append(secrets, secret)
+		secretIds = append(secretIds, secret.SecretId.ValueString())
+	}
+
+	// Reads are batched with the ones of the other secrets being refreshed.
+	secrets, err := r.client.BatchedSecrets(ctx, secretIds)
+	if err != nil {
+		addAPIError(&response.Diagnostics, "read secrets", err)
+		return
 	}
 
 	for projectIndex, projectItem := range secrets {
-		data.Secrets[projectIndex] = secretItemModel{
-			Key:            types.StringValue(projectItem.Key),
-			Value:          types.StringValue(projectItem.Value),
-			Note:           types.StringValue(projectItem.Note),
-			OrganizationId: types.StringValue(projectItem.OrganizationID),
-			SecretId:       types.StringValue(projectItem.ID),
+		item := newSecretItemModel(projectItem, r.valueChecksumKey)
+		// Values rotated outside of Terraform are not drift when unmanaged.
+		if !data.managesValue() && !data.Secrets[projectIndex].Value.IsNull() {
+			item.Value = data.Secrets[projectIndex].Value
 		}
+		data.Secrets[projectIndex] = item
 	}
 
 	// Save updated data into Terraform state
@@ -222,6 +536,21 @@ func (r *SecretResource) Update(ctx context.Context, request resource.UpdateRequ
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemSecret)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemSecret, "bitwarden_secret", "update", start, data.secretIds(), response.Diagnostics)
+	}()
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -231,34 +560,40 @@ func (r *SecretResource) Update(ctx context.Context, request resource.UpdateRequ
 	// }
 
 	var secrets []*bitwarden.SecretResponse
-	for _, secret := range data.Secrets {
-		secret, err := r.client.Secrets().Update(
+	for secretIndex, secret := range data.Secrets {
+		// Unmanaged values are written back as they are, read without the
+		// cache so a rotation made since the last read isn't reverted.
+		value := secret.Value.ValueString()
+		if !data.managesValue() {
+			remote, err := r.client.UncachedSecret(ctx, secret.SecretId.ValueString())
+			if err != nil {
+				addAttributeAPIError(&response.Diagnostics, path.Root("secrets").AtListIndex(secretIndex), "read secret", err)
+				return
+			}
+			value = remote.Value
+		}
+
+		secret, err := r.client.Secrets(ctx).Update(
 			secret.SecretId.ValueString(),
 			secret.Key.ValueString(),
-			secret.Value.ValueString(),
+			value,
 			secret.Note.ValueString(),
-			secret.OrganizationId.ValueString(),
-			[]string{secret.ProjectId.ValueString()},
+			r.secretOrganizationId(secret),
+			secretProjectIds(secret),
 		)
 		if err != nil {
-			response.Diagnostics.AddError(
-				"Error creating secret",
-				"Could not update secret, unexpected error: "+err.Error(),
-			)
+			addAttributeAPIError(&response.Diagnostics, path.Root("secrets").AtListIndex(secretIndex), "update secret", err)
 			return
 		}
 		secrets = append(secrets, secret)
 	}
 
 	for projectIndex, projectItem := range secrets {
-		data.Secrets[projectIndex] = secretItemModel{
-			SecretId:       types.StringValue(projectItem.ID),
-			Key:            types.StringValue(projectItem.Key),
-			Value:          types.StringValue(projectItem.Value),
-			Note:           types.StringValue(projectItem.Note),
-			OrganizationId: types.StringValue(projectItem.OrganizationID),
-			ProjectId:      types.StringValue(*projectItem.ProjectID),
+		item := newSecretItemModel(projectItem, r.valueChecksumKey)
+		if !data.managesValue() {
+			item = unmanagedSecretItem(item, data.Secrets[projectIndex])
 		}
+		data.Secrets[projectIndex] = item
 	}
 
 	// Save updated data into Terraform state
@@ -278,6 +613,21 @@ func (r *SecretResource) Delete(ctx context.Context, request resource.DeleteRequ
 		return
 	}
 
+	ctx = newLogSubsystem(ctx, logSubsystemSecret)
+	start := time.Now()
+	defer func() {
+		logOperation(ctx, logSubsystemSecret, "bitwarden_secret", "delete", start, data.secretIds(), response.Diagnostics)
+	}()
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := r.client.Do(httpReq)
@@ -286,23 +636,248 @@ func (r *SecretResource) Delete(ctx context.Context, request resource.DeleteRequ
 	//     return
 	// }
 
+	if data.OnDestroy.ValueString() == onDestroyAbandon {
+		tflog.SubsystemInfo(ctx, logSubsystemSecret, "abandoning secrets, they are only removed from the state")
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		response.Diagnostics.AddError(
+			"Secrets are protected from deletion",
+			"deletion_protection is enabled on this resource. Set deletion_protection to false and apply before destroying it, "+
+				"or set on_destroy to \"abandon\" to only remove it from the Terraform state.",
+		)
+		return
+	}
+
 	var secretsToDelete []string
 
 	for _, secret := range data.Secrets {
 		secretsToDelete = append(secretsToDelete, secret.SecretId.ValueString())
 	}
 
-	_, err := r.client.Secrets().Delete(secretsToDelete)
+	if len(secretsToDelete) == 0 {
+		return
+	}
+
+	deletion, err := r.client.Secrets(ctx).Delete(secretsToDelete)
 	if err != nil {
-		response.Diagnostics.AddError(
-			"Error creating secret",
-			"Could not delete secret, unexpected error: "+err.Error(),
-		)
+		addAPIError(&response.Diagnostics, "delete secrets", err)
 		return
 	}
 
+	var results []deleteItemResult
+	for _, item := range deletion.Data {
+		results = append(results, deleteItemResult{id: item.ID, err: item.Error})
+	}
+
+	failures := deleteItemErrors(results)
+	for secretIndex, secret := range data.Secrets {
+		if message, ok := failures[secret.SecretId.ValueString()]; ok {
+			response.Diagnostics.AddAttributeError(
+				path.Root("secrets").AtListIndex(secretIndex),
+				"Unable to delete secret",
+				fmt.Sprintf("The secret %q (%s) could not be deleted: %s", secret.Key.ValueString(), secret.SecretId.ValueString(), message),
+			)
+		}
+	}
+
 }
 
 func (r *SecretResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
 }
+
+// secretOrganizationId returns the organization of a secret, falling back to
+// the provider organization when the secret doesn't set one.
+func (r *SecretResource) secretOrganizationId(secret secretItemModel) string {
+	if secret.OrganizationId.IsNull() || secret.OrganizationId.IsUnknown() {
+		return r.organizationId
+	}
+	return secret.OrganizationId.ValueString()
+}
+
+// secretLocation returns the organization, project and key of a secret.
+func (r *SecretResource) secretLocation(secret secretItemModel) secretLocation {
+	location := secretLocation{organizationId: r.secretOrganizationId(secret), key: secret.Key.ValueString()}
+	if projectIds := secretProjectIds(secret); len(projectIds) > 0 {
+		location.projectId = projectIds[0]
+	}
+	return location
+}
+
+// existingSecrets returns the secrets already using the keys of secrets,
+// listing each organization once.
+func (r *SecretResource) existingSecrets(ctx context.Context, secrets []secretItemModel) (map[secretLocation][]bitwarden.SecretResponse, error) {
+	keys := make(map[string]map[string]bool)
+	for _, secret := range secrets {
+		organizationId := r.secretOrganizationId(secret)
+		if keys[organizationId] == nil {
+			keys[organizationId] = make(map[string]bool)
+		}
+		keys[organizationId][secret.Key.ValueString()] = true
+	}
+
+	existing := make(map[secretLocation][]bitwarden.SecretResponse)
+	for organizationId, organizationKeys := range keys {
+		organizationSecrets, err := existingSecrets(ctx, r.client, organizationId, organizationKeys)
+		if err != nil {
+			return nil, err
+		}
+		for location, locationSecrets := range organizationSecrets {
+			existing[location] = locationSecrets
+		}
+	}
+	return existing, nil
+}
+
+// createSecret creates a secret. When secrets of its project already use
+// its key, they are resolved as onDuplicateKey says first. An adopted secret
+// keeps its value unless manageValue is set.
+func (r *SecretResource) createSecret(ctx context.Context, secret secretItemModel, duplicates []bitwarden.SecretResponse, onDuplicateKey string, manageValue bool, secretPath path.Path, diags *diag.Diagnostics) *bitwarden.SecretResponse {
+	var duplicateIds []string
+	for _, duplicate := range duplicates {
+		duplicateIds = append(duplicateIds, duplicate.ID)
+	}
+
+	switch {
+	case len(duplicates) == 0:
+	case onDuplicateKey == onDuplicateKeyAdopt:
+		if len(duplicates) > 1 {
+			diags.AddAttributeError(
+				secretPath.AtName("key"),
+				"Secret key is used several times",
+				fmt.Sprintf("The key %q is already used by %d secrets of the project (%s), which one to adopt is ambiguous. "+
+					"Delete the extra secrets, or replace them with on_duplicate_key = \"overwrite\" instead of adopting.",
+					secret.Key.ValueString(), len(duplicates), strings.Join(duplicateIds, ", ")),
+			)
+			return nil
+		}
+
+		tflog.SubsystemInfo(ctx, logSubsystemSecret, "adopting existing secret", map[string]any{"key": secret.Key.ValueString(), "secret_id": duplicateIds[0]})
+		// Unmanaged values are kept, read without the cache so a rotation
+		// made since the duplicates were looked up isn't reverted.
+		value := secret.Value.ValueString()
+		if !manageValue {
+			remote, err := r.client.UncachedSecret(ctx, duplicateIds[0])
+			if err != nil {
+				addAttributeAPIError(diags, secretPath, "read secret", err)
+				return nil
+			}
+			value = remote.Value
+		}
+		adopted, err := r.client.Secrets(ctx).Update(
+			duplicateIds[0],
+			secret.Key.ValueString(),
+			value,
+			secret.Note.ValueString(),
+			r.secretOrganizationId(secret),
+			secretProjectIds(secret),
+		)
+		if err != nil {
+			addAttributeAPIError(diags, secretPath, "adopt secret", err)
+			return nil
+		}
+		return adopted
+	case onDuplicateKey == onDuplicateKeyOverwrite:
+		tflog.SubsystemInfo(ctx, logSubsystemSecret, "deleting secrets using the same key", map[string]any{"key": secret.Key.ValueString(), "secret_ids": duplicateIds})
+		deletion, err := r.client.Secrets(ctx).Delete(duplicateIds)
+		if err != nil {
+			addAttributeAPIError(diags, secretPath, "delete the secrets using the same key", err)
+			return nil
+		}
+
+		var results []deleteItemResult
+		for _, item := range deletion.Data {
+			results = append(results, deleteItemResult{id: item.ID, err: item.Error})
+		}
+		for id, message := range deleteItemErrors(results) {
+			diags.AddAttributeError(
+				secretPath,
+				"Unable to delete secret",
+				fmt.Sprintf("The secret %s using the key %q could not be deleted: %s", id, secret.Key.ValueString(), message),
+			)
+		}
+		if diags.HasError() {
+			return nil
+		}
+	default:
+		diags.AddAttributeError(
+			secretPath.AtName("key"),
+			"Secret key already exists",
+			fmt.Sprintf("The key %q is already used in the project by the secrets %s. Import them, delete them, "+
+				"or set on_duplicate_key to \"adopt\" or \"overwrite\".",
+				secret.Key.ValueString(), strings.Join(duplicateIds, ", ")),
+		)
+		return nil
+	}
+
+	created, err := r.client.Secrets(ctx).Create(
+		secret.Key.ValueString(),
+		secret.Value.ValueString(),
+		secret.Note.ValueString(),
+		r.secretOrganizationId(secret),
+		secretProjectIds(secret),
+	)
+	if err != nil {
+		addAttributeAPIError(diags, secretPath, "create secret", err)
+		return nil
+	}
+	return created
+}
+
+// secretProjectIds returns the projects a secret is assigned to.
+func secretProjectIds(secret secretItemModel) []string {
+	if secret.ProjectId.IsNull() || secret.ProjectId.IsUnknown() {
+		return nil
+	}
+	return []string{secret.ProjectId.ValueString()}
+}
+
+// newSecretItemModel maps a secret returned by the API to its state representation.
+func newSecretItemModel(secret *bitwarden.SecretResponse, valueChecksumKey []byte) secretItemModel {
+	item := secretItemModel{
+		Key:            types.StringValue(secret.Key),
+		Value:          types.StringValue(secret.Value),
+		ValueSha256:    types.StringValue(valueSha256(secret.Value, valueChecksumKey)),
+		Note:           types.StringValue(secret.Note),
+		OrganizationId: types.StringValue(secret.OrganizationID),
+		SecretId:       types.StringValue(secret.ID),
+		ProjectId:      types.StringNull(),
+	}
+
+	if secret.ProjectID != nil {
+		item.ProjectId = types.StringValue(*secret.ProjectID)
+	}
+
+	return item
+}
+
+// unmanagedSecretItem keeps the planned value of a secret whose value is not
+// managed, along with its planned checksum when known.
+func unmanagedSecretItem(item secretItemModel, planned secretItemModel) secretItemModel {
+	item.Value = planned.Value
+	if !planned.ValueSha256.IsNull() && !planned.ValueSha256.IsUnknown() {
+		item.ValueSha256 = planned.ValueSha256
+	}
+	return item
+}
+
+// managesValue reports whether the secret values are managed, which they are
+// unless manage_value is false.
+func (m *SecretResourceModel) managesValue() bool {
+	return m.ManageValue.IsNull() || m.ManageValue.IsUnknown() || m.ManageValue.ValueBool()
+}
+
+// secretIds returns the ids of the secrets, nil when the model couldn't be read.
+func (m *SecretResourceModel) secretIds() []string {
+	if m == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(m.Secrets))
+	for _, item := range m.Secrets {
+		ids = append(ids, item.SecretId.ValueString())
+	}
+	return ids
+}
@@ -0,0 +1,751 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecretResourceValidateConfigKeyPattern(t *testing.T) {
+	testCases := map[string]struct {
+		providerPattern *regexp.Regexp
+		resourcePattern types.String
+		key             string
+		expectErr       bool
+	}{
+		"no policy": {
+			key: "db-password",
+		},
+		"resource preset": {
+			resourcePattern: types.StringValue(keyPatternEnvVarSafe),
+			key:             "db-password",
+			expectErr:       true,
+		},
+		"resource preset match": {
+			resourcePattern: types.StringValue(keyPatternEnvVarSafe),
+			key:             "DB_PASSWORD",
+		},
+		"provider pattern": {
+			providerPattern: regexp.MustCompile(keyPatternPresets[keyPatternEnvVarSafe]),
+			key:             "1_PASSWORD",
+			expectErr:       true,
+		},
+		"resource overrides provider": {
+			providerPattern: regexp.MustCompile(keyPatternPresets[keyPatternEnvVarSafe]),
+			resourcePattern: types.StringValue(`^[a-z-]+$`),
+			key:             "db-password",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secretResource := &SecretResource{keyPattern: testCase.providerPattern}
+			config := testResourceConfig(t, secretResource, SecretResourceModel{
+				Timeouts:   testNullTimeouts,
+				KeyPattern: testCase.resourcePattern,
+				Secrets: []secretItemModel{
+					{Key: types.StringValue(testCase.key), Value: types.StringValue("value")},
+				},
+			})
+
+			response := &resource.ValidateConfigResponse{}
+			secretResource.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSecretResourceUpdateMovesProject(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	source := client.addProject(testOrganizationId, "source")
+	target := client.addProject(testOrganizationId, "target")
+	secret := client.addSecret(testOrganizationId, source.ID, "DB_PASSWORD", "s3cr3t")
+
+	secretResource := &SecretResource{client: newTestAPIClient(client)}
+	plan := SecretResourceModel{
+		Timeouts: testNullTimeouts,
+		Id:       types.StringValue("resource"),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue(secret.Key),
+				Value:          types.StringValue(secret.Value),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringValue(secret.ID),
+				ProjectId:      types.StringValue(target.ID),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+		},
+	}
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	response := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+	}
+	secretResource.Update(ctx, resource.UpdateRequest{
+		Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)},
+	}, response)
+
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	var state SecretResourceModel
+	response.Diagnostics.Append(response.State.Get(ctx, &state)...)
+	if state.Secrets[0].SecretId.ValueString() != secret.ID || state.Secrets[0].ProjectId.ValueString() != target.ID {
+		t.Fatalf("expected the secret to move in place, got: %+v", state.Secrets[0])
+	}
+
+	moved, err := client.Secrets().Get(secret.ID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if *moved.ProjectID != target.ID || moved.Value != "s3cr3t" {
+		t.Fatalf("unexpected remote secret: %+v", moved)
+	}
+}
+
+func TestSecretResourceModifyPlanInaccessibleProject(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	source := client.addProject(testOrganizationId, "source")
+	target := client.addProject(testOrganizationId, "target")
+
+	testCases := map[string]struct {
+		projectId     string
+		expectWarning bool
+	}{
+		"unchanged":    {projectId: source.ID},
+		"accessible":   {projectId: target.ID},
+		"inaccessible": {projectId: "6c1b4e5a-0f0e-4b8e-8d4b-3f5c2a1e9d70", expectWarning: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secretResource := &SecretResource{client: newTestAPIClient(client)}
+			item := secretItemModel{
+				Key:            types.StringValue("DB_PASSWORD"),
+				Value:          types.StringValue("s3cr3t"),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringValue("3d8f6b0e-4f55-4c59-b1a0-9e0c6f5d2a11"),
+				ProjectId:      types.StringValue(source.ID),
+				OrganizationId: types.StringValue(testOrganizationId),
+			}
+			state := SecretResourceModel{Id: types.StringValue("resource"), Secrets: []secretItemModel{item}, Timeouts: testNullTimeouts}
+			item.ProjectId = types.StringValue(testCase.projectId)
+			plan := SecretResourceModel{Id: types.StringValue("resource"), Secrets: []secretItemModel{item}, Timeouts: testNullTimeouts}
+
+			resourceSchema := testResourceSchema(t, secretResource)
+			planned := tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)}
+			response := &resource.ModifyPlanResponse{Plan: planned}
+			secretResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, state)},
+				Plan:  planned,
+			}, response)
+
+			if response.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if (response.Diagnostics.WarningsCount() > 0) != testCase.expectWarning {
+				t.Fatalf("unexpected warnings: %v", response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSecretResourceModifyPlanTimeout(t *testing.T) {
+	ctx := context.Background()
+	client := &slowClient{fakeClient: newFakeClient(), release: make(chan struct{})}
+	defer close(client.release)
+	source := client.addProject(testOrganizationId, "source")
+	target := client.addProject(testOrganizationId, "target")
+
+	secretResource := &SecretResource{client: newTestAPIClient(client)}
+	item := secretItemModel{
+		Key:            types.StringValue("DB_PASSWORD"),
+		Value:          types.StringValue("s3cr3t"),
+		Note:           types.StringValue(""),
+		SecretId:       types.StringValue("3d8f6b0e-4f55-4c59-b1a0-9e0c6f5d2a11"),
+		ProjectId:      types.StringValue(source.ID),
+		OrganizationId: types.StringValue(testOrganizationId),
+	}
+	state := SecretResourceModel{Id: types.StringValue("resource"), Secrets: []secretItemModel{item}, Timeouts: testReadTimeouts("10ms")}
+	item.ProjectId = types.StringValue(target.ID)
+	plan := SecretResourceModel{Id: types.StringValue("resource"), Secrets: []secretItemModel{item}, Timeouts: testReadTimeouts("10ms")}
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	planned := tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)}
+	prior := tfsdk.State{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, state)}
+	response := &resource.ModifyPlanResponse{Plan: planned}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		secretResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			State: prior,
+			Plan:  planned,
+		}, response)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the project read to be bounded by the read timeout")
+	}
+	if response.Diagnostics.HasError() || response.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a warning for the timed out read, got %v", response.Diagnostics)
+	}
+}
+
+func TestSecretResourceModifyPlanPreflight(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	visible := client.addProject(testOrganizationId, "visible")
+	hidden := "6c1b4e5a-0f0e-4b8e-8d4b-3f5c2a1e9d70"
+
+	otherOrganizationId := "8a4e2c1d-7b3f-4d5e-9c6a-0f1b2d3e4c5a"
+	other := client.addProject(otherOrganizationId, "other")
+
+	testCases := map[string]struct {
+		projectId      types.String
+		organizationId types.String
+		expectWarning  bool
+	}{
+		"visible":            {projectId: types.StringValue(visible.ID)},
+		"invisible":          {projectId: types.StringValue(hidden), expectWarning: true},
+		"no project":         {projectId: types.StringNull()},
+		"unknown":            {projectId: types.StringUnknown()},
+		"other organization": {projectId: types.StringValue(other.ID), organizationId: types.StringValue(otherOrganizationId)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secretResource := &SecretResource{
+				client:             newTestAPIClient(client),
+				organizationId:     testOrganizationId,
+				accessibleProjects: map[string]string{visible.ID: visible.Name},
+			}
+			organizationId := testCase.organizationId
+			if organizationId.IsNull() {
+				organizationId = types.StringValue(testOrganizationId)
+			}
+			plan := SecretResourceModel{
+				Timeouts: testNullTimeouts,
+				Id:       types.StringUnknown(),
+				Secrets: []secretItemModel{
+					{
+						Key:            types.StringValue("DB_PASSWORD"),
+						Value:          types.StringValue("s3cr3t"),
+						Note:           types.StringValue(""),
+						SecretId:       types.StringUnknown(),
+						ProjectId:      testCase.projectId,
+						OrganizationId: organizationId,
+					},
+				},
+			}
+
+			resourceSchema := testResourceSchema(t, secretResource)
+			planned := tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)}
+			response := &resource.ModifyPlanResponse{Plan: planned}
+			secretResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+				Plan:  planned,
+			}, response)
+
+			if response.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if (response.Diagnostics.WarningsCount() > 0) != testCase.expectWarning {
+				t.Fatalf("unexpected warnings: %v", response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSecretResourceDeleteProtection(t *testing.T) {
+	testCases := map[string]struct {
+		deletionProtection bool
+		onDestroy          string
+		expectErr          bool
+		expectDeleted      bool
+	}{
+		"delete":            {onDestroy: onDestroyDelete, expectDeleted: true},
+		"protected":         {deletionProtection: true, onDestroy: onDestroyDelete, expectErr: true},
+		"abandon":           {onDestroy: onDestroyAbandon},
+		"protected abandon": {deletionProtection: true, onDestroy: onDestroyAbandon},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			secret := client.addSecret(testOrganizationId, "", "DB_PASSWORD", "s3cr3t")
+
+			secretResource := &SecretResource{client: newTestAPIClient(client)}
+			state := SecretResourceModel{
+				Timeouts:           testNullTimeouts,
+				Id:                 types.StringValue("resource"),
+				DeletionProtection: types.BoolValue(testCase.deletionProtection),
+				OnDestroy:          types.StringValue(testCase.onDestroy),
+				Secrets: []secretItemModel{
+					{Key: types.StringValue(secret.Key), Value: types.StringValue(secret.Value), SecretId: types.StringValue(secret.ID)},
+				},
+			}
+
+			response := &resource.DeleteResponse{}
+			secretResource.Delete(context.Background(), resource.DeleteRequest{
+				State: testResourceState(t, secretResource, state),
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if _, err := client.Secrets().Get(secret.ID); (err != nil) != testCase.expectDeleted {
+				t.Fatalf("unexpected remote secret state, deleted: %t", err != nil)
+			}
+		})
+	}
+}
+
+func TestSecretResourceDeleteItemErrors(t *testing.T) {
+	client := newFakeClient()
+	deleted := client.addSecret(testOrganizationId, "", "DELETED", "value")
+	locked := client.addSecret(testOrganizationId, "", "LOCKED", "value")
+	client.deleteErrors[locked.ID] = "You do not have permission to delete this secret"
+
+	secretResource := &SecretResource{client: newTestAPIClient(client)}
+	state := SecretResourceModel{
+		Timeouts:  testNullTimeouts,
+		Id:        types.StringValue("resource"),
+		OnDestroy: types.StringValue(onDestroyDelete),
+		Secrets: []secretItemModel{
+			{Key: types.StringValue(deleted.Key), SecretId: types.StringValue(deleted.ID)},
+			{Key: types.StringValue("GONE"), SecretId: types.StringValue("0d7e9c3a-52b1-4f6e-a8d4-7c1b2e3f4a5b")},
+			{Key: types.StringValue(locked.Key), SecretId: types.StringValue(locked.ID)},
+		},
+	}
+
+	response := &resource.DeleteResponse{}
+	secretResource.Delete(context.Background(), resource.DeleteRequest{
+		State: testResourceState(t, secretResource, state),
+	}, response)
+
+	if response.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected only the locked secret to fail, got: %v", response.Diagnostics)
+	}
+	if detail := response.Diagnostics[0].Detail(); !strings.Contains(detail, locked.ID) || !strings.Contains(detail, "permission") {
+		t.Fatalf("expected the failed id and its message, got: %s", detail)
+	}
+	if _, err := client.Secrets().Get(deleted.ID); err == nil {
+		t.Fatal("expected the other secret to be deleted")
+	}
+}
+
+func TestSecretResourceCreateDuplicateKey(t *testing.T) {
+	testCases := map[string]struct {
+		uniqueKeyInProject bool
+		onDuplicateKey     string
+		adoptExisting      bool
+		duplicates         int
+		expectErr          bool
+		expectAdopted      bool
+		expectSecrets      int
+	}{
+		"unchecked":         {onDuplicateKey: onDuplicateKeyFail, duplicates: 1, expectSecrets: 2},
+		"no duplicate":      {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyFail, expectSecrets: 1},
+		"fail":              {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyFail, duplicates: 1, expectErr: true, expectSecrets: 1},
+		"adopt":             {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyAdopt, duplicates: 1, expectAdopted: true, expectSecrets: 1},
+		"adopt ambiguous":   {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyAdopt, duplicates: 2, expectErr: true, expectSecrets: 2},
+		"overwrite":         {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyOverwrite, duplicates: 1, expectSecrets: 1},
+		"overwrite several": {uniqueKeyInProject: true, onDuplicateKey: onDuplicateKeyOverwrite, duplicates: 2, expectSecrets: 1},
+		"adopt existing":    {adoptExisting: true, onDuplicateKey: onDuplicateKeyFail, duplicates: 1, expectAdopted: true, expectSecrets: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeClient()
+			project := client.addProject(testOrganizationId, "project")
+			other := client.addProject(testOrganizationId, "other")
+			// The same key in another project is not a duplicate.
+			client.addSecret(testOrganizationId, other.ID, "DB_PASSWORD", "other")
+
+			var duplicateIds []string
+			for i := 0; i < testCase.duplicates; i++ {
+				duplicateIds = append(duplicateIds, client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "old").ID)
+			}
+
+			secretResource := &SecretResource{client: newTestAPIClient(client), organizationId: testOrganizationId}
+			plan := SecretResourceModel{
+				Timeouts:           testNullTimeouts,
+				Id:                 types.StringUnknown(),
+				UniqueKeyInProject: types.BoolValue(testCase.uniqueKeyInProject),
+				OnDuplicateKey:     types.StringValue(testCase.onDuplicateKey),
+				AdoptExisting:      types.BoolValue(testCase.adoptExisting),
+				Secrets: []secretItemModel{
+					{
+						Key:            types.StringValue("DB_PASSWORD"),
+						Value:          types.StringValue("new"),
+						Note:           types.StringValue(""),
+						SecretId:       types.StringUnknown(),
+						ProjectId:      types.StringValue(project.ID),
+						OrganizationId: types.StringUnknown(),
+					},
+				},
+			}
+
+			resourceSchema := testResourceSchema(t, secretResource)
+			response := &resource.CreateResponse{
+				State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+			}
+			secretResource.Create(ctx, resource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)},
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+
+			var secrets []string
+			for _, secret := range client.secrets {
+				if secret.ProjectID != nil && *secret.ProjectID == project.ID {
+					secrets = append(secrets, secret.ID)
+				}
+			}
+			if len(secrets) != testCase.expectSecrets {
+				t.Fatalf("expected %d secrets in the project, got %d", testCase.expectSecrets, len(secrets))
+			}
+
+			if testCase.expectErr {
+				return
+			}
+
+			var state SecretResourceModel
+			response.Diagnostics.Append(response.State.Get(ctx, &state)...)
+			secretId := state.Secrets[0].SecretId.ValueString()
+			if adopted := testCase.duplicates > 0 && secretId == duplicateIds[0]; adopted != testCase.expectAdopted {
+				t.Fatalf("unexpected secret id %s, adopted: %t", secretId, adopted)
+			}
+			if secret := client.secrets[secretId]; secret.Value != "new" {
+				t.Fatalf("expected the configured value, got %q", secret.Value)
+			}
+		})
+	}
+}
+
+func TestSecretResourceValidateConfigAdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		onDuplicateKey types.String
+		expectErr      bool
+	}{
+		"unset":     {onDuplicateKey: types.StringNull()},
+		"adopt":     {onDuplicateKey: types.StringValue(onDuplicateKeyAdopt)},
+		"overwrite": {onDuplicateKey: types.StringValue(onDuplicateKeyOverwrite), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secretResource := &SecretResource{}
+			config := testResourceConfig(t, secretResource, SecretResourceModel{
+				Timeouts:       testNullTimeouts,
+				AdoptExisting:  types.BoolValue(true),
+				OnDuplicateKey: testCase.onDuplicateKey,
+				Secrets: []secretItemModel{
+					{Key: types.StringValue("DB_PASSWORD"), Value: types.StringValue("value")},
+				},
+			})
+
+			response := &resource.ValidateConfigResponse{}
+			secretResource.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSecretResourceModifyPlanValueSha256(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("checksum-key")
+	secretResource := &SecretResource{client: newTestAPIClient(newFakeClient()), valueChecksumKey: key}
+	plan := SecretResourceModel{
+		Timeouts: testNullTimeouts,
+		Id:       types.StringUnknown(),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue("DB_PASSWORD"),
+				Value:          types.StringValue("s3cr3t"),
+				ValueSha256:    types.StringUnknown(),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringUnknown(),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+			{
+				Key:            types.StringValue("API_KEY"),
+				Value:          types.StringUnknown(),
+				ValueSha256:    types.StringUnknown(),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringUnknown(),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+		},
+	}
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	planned := tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)}
+	response := &resource.ModifyPlanResponse{Plan: planned}
+	secretResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+		Plan:  planned,
+	}, response)
+
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	var modified SecretResourceModel
+	response.Diagnostics.Append(response.Plan.Get(ctx, &modified)...)
+	if checksum := modified.Secrets[0].ValueSha256.ValueString(); checksum != valueSha256("s3cr3t", key) {
+		t.Fatalf("expected the keyed checksum of the value, got %q", checksum)
+	}
+	if !modified.Secrets[1].ValueSha256.IsUnknown() {
+		t.Fatalf("expected the checksum of an unknown value to stay unknown, got %s", modified.Secrets[1].ValueSha256)
+	}
+}
+
+func TestSecretResourceUnmanagedValue(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	project := client.addProject(testOrganizationId, "project")
+	secret := client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "rotated")
+
+	secretResource := &SecretResource{client: newTestAPIClient(client)}
+	model := SecretResourceModel{
+		Timeouts:    testNullTimeouts,
+		Id:          types.StringValue("resource"),
+		ManageValue: types.BoolValue(false),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue(secret.Key),
+				Value:          types.StringValue("initial"),
+				ValueSha256:    types.StringValue(valueSha256("initial", nil)),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringValue(secret.ID),
+				ProjectId:      types.StringValue(project.ID),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+		},
+	}
+
+	readResponse := &resource.ReadResponse{State: testResourceState(t, secretResource, model)}
+	secretResource.Read(ctx, resource.ReadRequest{State: readResponse.State}, readResponse)
+	if readResponse.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResponse.Diagnostics)
+	}
+
+	var refreshed SecretResourceModel
+	readResponse.Diagnostics.Append(readResponse.State.Get(ctx, &refreshed)...)
+	if refreshed.Secrets[0].Value.ValueString() != "initial" {
+		t.Fatalf("expected the rotated value to be ignored, got %q", refreshed.Secrets[0].Value.ValueString())
+	}
+	if refreshed.Secrets[0].ValueSha256.ValueString() != valueSha256("rotated", nil) {
+		t.Fatal("expected the checksum to follow the remote value")
+	}
+
+	// Updating the note keeps the rotated value.
+	plan := refreshed
+	plan.Secrets = []secretItemModel{refreshed.Secrets[0]}
+	plan.Secrets[0].Note = types.StringValue("rotated by the application")
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	updateResponse := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+	}
+	secretResource.Update(ctx, resource.UpdateRequest{
+		Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)},
+	}, updateResponse)
+	if updateResponse.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResponse.Diagnostics)
+	}
+
+	var updated SecretResourceModel
+	updateResponse.Diagnostics.Append(updateResponse.State.Get(ctx, &updated)...)
+	if updated.Secrets[0].Value.ValueString() != "initial" || updated.Secrets[0].ValueSha256.ValueString() != valueSha256("rotated", nil) {
+		t.Fatalf("unexpected state: %+v", updated.Secrets[0])
+	}
+
+	remote, err := client.Secrets().Get(secret.ID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if remote.Value != "rotated" || remote.Note != "rotated by the application" {
+		t.Fatalf("unexpected remote secret: %+v", remote)
+	}
+}
+
+func TestSecretResourceUpdateUnmanagedValueAfterCachedRead(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	project := client.addProject(testOrganizationId, "project")
+	secret := client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "rotated")
+
+	apiClient := newTestCachedAPIClient(client, time.Minute)
+	if _, err := apiClient.Secrets(ctx).Get(secret.ID); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// The application rotates the value again after the cached read.
+	if _, err := client.Secrets().Update(secret.ID, secret.Key, "rotated again", secret.Note, testOrganizationId, []string{project.ID}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	secretResource := &SecretResource{client: apiClient}
+	plan := SecretResourceModel{
+		Timeouts:    testNullTimeouts,
+		Id:          types.StringValue("resource"),
+		ManageValue: types.BoolValue(false),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue(secret.Key),
+				Value:          types.StringValue("initial"),
+				ValueSha256:    types.StringValue(valueSha256("rotated", nil)),
+				Note:           types.StringValue("rotated by the application"),
+				SecretId:       types.StringValue(secret.ID),
+				ProjectId:      types.StringValue(project.ID),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+		},
+	}
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	response := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+	}
+	secretResource.Update(ctx, resource.UpdateRequest{
+		Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)},
+	}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	remote, err := client.Secrets().Get(secret.ID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if remote.Value != "rotated again" {
+		t.Fatalf("expected the latest rotated value to be kept, got %q", remote.Value)
+	}
+}
+
+func TestSecretResourceAdoptUnmanagedValueAfterCachedLookup(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	project := client.addProject(testOrganizationId, "project")
+	secret := client.addSecret(testOrganizationId, project.ID, "DB_PASSWORD", "rotated")
+
+	apiClient := newTestCachedAPIClient(client, time.Minute)
+	if _, err := existingSecrets(ctx, apiClient, testOrganizationId, map[string]bool{secret.Key: true}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// The application rotates the value again after the cached lookup.
+	if _, err := client.Secrets().Update(secret.ID, secret.Key, "rotated again", secret.Note, testOrganizationId, []string{project.ID}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	secretResource := &SecretResource{client: apiClient, organizationId: testOrganizationId}
+	plan := SecretResourceModel{
+		Timeouts:           testNullTimeouts,
+		Id:                 types.StringUnknown(),
+		UniqueKeyInProject: types.BoolValue(false),
+		OnDuplicateKey:     types.StringValue(onDuplicateKeyFail),
+		AdoptExisting:      types.BoolValue(true),
+		ManageValue:        types.BoolValue(false),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue(secret.Key),
+				Value:          types.StringValue("initial"),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringUnknown(),
+				ProjectId:      types.StringValue(project.ID),
+				OrganizationId: types.StringUnknown(),
+			},
+		},
+	}
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	response := &resource.CreateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)},
+	}
+	secretResource.Create(ctx, resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)},
+	}, response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	remote, err := client.Secrets().Get(secret.ID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if remote.Value != "rotated again" {
+		t.Fatalf("expected the latest rotated value to be kept, got %q", remote.Value)
+	}
+}
+
+func TestSecretResourceModifyPlanUnmanagedValue(t *testing.T) {
+	ctx := context.Background()
+	secretResource := &SecretResource{client: newTestAPIClient(newFakeClient())}
+	state := SecretResourceModel{
+		Timeouts:    testNullTimeouts,
+		Id:          types.StringValue("resource"),
+		ManageValue: types.BoolValue(false),
+		Secrets: []secretItemModel{
+			{
+				Key:            types.StringValue("DB_PASSWORD"),
+				Value:          types.StringValue("initial"),
+				ValueSha256:    types.StringValue(valueSha256("rotated", nil)),
+				Note:           types.StringValue(""),
+				SecretId:       types.StringValue("0d7e9c3a-52b1-4f6e-a8d4-7c1b2e3f4a5b"),
+				OrganizationId: types.StringValue(testOrganizationId),
+			},
+		},
+	}
+	plan := state
+	plan.Secrets = []secretItemModel{state.Secrets[0], state.Secrets[0]}
+	plan.Secrets[0].Value = types.StringValue("changed")
+	plan.Secrets[1].Key = types.StringValue("API_KEY")
+	plan.Secrets[1].SecretId = types.StringUnknown()
+
+	resourceSchema := testResourceSchema(t, secretResource)
+	planned := tfsdk.Plan{Schema: resourceSchema, Raw: testResourceValue(t, secretResource, plan)}
+	response := &resource.ModifyPlanResponse{Plan: planned}
+	secretResource.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		State: testResourceState(t, secretResource, state),
+		Plan:  planned,
+	}, response)
+
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+	}
+
+	var modified SecretResourceModel
+	response.Diagnostics.Append(response.Plan.Get(ctx, &modified)...)
+	if modified.Secrets[0].ValueSha256.ValueString() != valueSha256("rotated", nil) {
+		t.Fatalf("expected the checksum of the remote value, got %s", modified.Secrets[0].ValueSha256)
+	}
+	if !modified.Secrets[1].ValueSha256.IsUnknown() {
+		t.Fatalf("expected the checksum of a new secret to be unknown, got %s", modified.Secrets[1].ValueSha256)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"strings"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+)
+
+const (
+	// onDestroyDelete deletes the remote objects when the resource is destroyed.
+	onDestroyDelete = "delete"
+	// onDestroyAbandon only removes the resource from the state.
+	onDestroyAbandon = "abandon"
+)
+
+// deletionProtectionAttribute is the deletion_protection attribute shared by
+// the secret and project resources.
+func deletionProtectionAttribute(objects string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "prevent the " + objects + " from being deleted, it must be set to false and applied before the resource can be destroyed",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+}
+
+// onDestroyAttribute is the on_destroy attribute shared by the secret and
+// project resources.
+func onDestroyAttribute(objects string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "what happens to the " + objects + " when the resource is destroyed: `delete` removes them from bitwarden secrets manager, `abandon` only removes them from the Terraform state",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(onDestroyDelete),
+		Validators: []validator.String{
+			validators.OneOf(onDestroyDelete, onDestroyAbandon),
+		},
+	}
+}
+
+// deleteItemResult is the outcome of deleting one id in a batch delete.
+type deleteItemResult struct {
+	id  string
+	err *string
+}
+
+// deleteItemErrors returns the error message of every id which could not be
+// deleted, indexed by id. Ids which no longer exist are already in the
+// desired state and are not reported.
+func deleteItemErrors(results []deleteItemResult) map[string]string {
+	failures := make(map[string]string)
+	for _, result := range results {
+		if result.err == nil || *result.err == "" || isNotFoundMessage(*result.err) {
+			continue
+		}
+		failures[result.id] = *result.err
+	}
+	return failures
+}
+
+// isNotFoundMessage reports whether an API error message means the object doesn't exist.
+func isNotFoundMessage(message string) bool {
+	return strings.Contains(strings.ToLower(message), "not found")
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	bitwarden "github.com/bitwarden/sdk-go"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"terraform-provider-bitwarden-secrets-manager/internal/validators"
+)
+
+const (
+	// onDuplicateKeyFail refuses to create a secret whose key is already used
+	// in its project.
+	onDuplicateKeyFail = "fail"
+	// onDuplicateKeyAdopt takes over the existing secret, updating it to
+	// match the configuration.
+	onDuplicateKeyAdopt = "adopt"
+	// onDuplicateKeyOverwrite deletes the existing secrets before creating
+	// the new one.
+	onDuplicateKeyOverwrite = "overwrite"
+)
+
+// onDuplicateKeyAttribute is the on_duplicate_key attribute of the secret
+// resource.
+func onDuplicateKeyAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "what happens on create when unique_key_in_project finds secrets of the project already using the key: " +
+			"`fail` reports an error, `adopt` takes over the existing secret and updates it to match the configuration, " +
+			"`overwrite` deletes the existing secrets and creates a new one",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onDuplicateKeyFail),
+		Validators: []validator.String{
+			validators.OneOf(onDuplicateKeyFail, onDuplicateKeyAdopt, onDuplicateKeyOverwrite),
+		},
+	}
+}
+
+// adoptExistingAttribute is the adopt_existing attribute shared by the secret
+// and project resources. lookup says how existing objects are matched.
+func adoptExistingAttribute(objects string, lookup string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "on create, take over the existing " + objects + " with the same " + lookup + " instead of creating duplicates, updating them to match the configuration, " +
+			"to bring " + objects + " made outside of Terraform under management",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// secretLocation identifies a key in a project, the project is empty for
+// secrets which don't belong to one.
+type secretLocation struct {
+	organizationId string
+	projectId      string
+	key            string
+}
+
+// existingSecrets returns the secrets of an organization using one of keys,
+// indexed by location. Secret identifiers don't include the project, so the
+// secrets with a matching key are read by id.
+func existingSecrets(ctx context.Context, client *apiClient, organizationId string, keys map[string]bool) (map[secretLocation][]bitwarden.SecretResponse, error) {
+	identifiers, err := client.Secrets(ctx).List(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretIds []string
+	for _, identifier := range identifiers.Data {
+		if keys[identifier.Key] {
+			secretIds = append(secretIds, identifier.ID)
+		}
+	}
+
+	existing := make(map[secretLocation][]bitwarden.SecretResponse)
+	if len(secretIds) == 0 {
+		return existing, nil
+	}
+
+	secrets, err := client.Secrets(ctx).GetByIDS(secretIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secrets.Data {
+		location := secretLocation{organizationId: secret.OrganizationID, key: secret.Key}
+		if secret.ProjectID != nil {
+			location.projectId = *secret.ProjectID
+		}
+		existing[location] = append(existing[location], secret)
+	}
+	return existing, nil
+}
+
+// existingProjects returns the projects of an organization, indexed by name.
+func existingProjects(ctx context.Context, client *apiClient, organizationId string) (map[string][]bitwarden.ProjectResponse, error) {
+	projects, err := client.Projects(ctx).List(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string][]bitwarden.ProjectResponse)
+	for _, project := range projects.Data {
+		existing[project.Name] = append(existing[project.Name], project)
+	}
+	return existing, nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"time"
+)
+
+// defaultOperationTimeout bounds an operation when its timeout is not configured.
+const defaultOperationTimeout = 5 * time.Minute
+
+// operationTimeoutsBlock is the timeouts block shared by the resources.
+func operationTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "Timeout of the create operation, such as `30s` or `2m`. Defaults to 5m.",
+		ReadDescription:   "Timeout of the read operation. Defaults to 5m.",
+		UpdateDescription: "Timeout of the update operation. Defaults to 5m.",
+		DeleteDescription: "Timeout of the delete operation. Defaults to 5m.",
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of the Bitwarden Secrets Manager web vault, in characters.
+// The server limits the encrypted values, which are longer than the plain
+// text, so longer values are rejected when applied.
+const (
+	maxSecretKeyLength   = 500
+	maxSecretValueLength = 25000
+	maxSecretNoteLength  = 7000
+	maxProjectNameLength = 500
+)
+
+// validateSecretItems checks the secrets of a configuration without the API:
+// keys must not be blank, values must fit the server limits and a key must
+// not be configured twice for the same project.
+func validateSecretItems(secrets []secretItemModel, diags *diag.Diagnostics) {
+	seen := make(map[string]int)
+	for secretIndex, secret := range secrets {
+		secretPath := path.Root("secrets").AtListIndex(secretIndex)
+
+		validateLength(secret.Key, true, maxSecretKeyLength, secretPath.AtName("key"), "Secret key", diags)
+		validateLength(secret.Value, false, maxSecretValueLength, secretPath.AtName("value"), "Secret value", diags)
+		validateLength(secret.Note, false, maxSecretNoteLength, secretPath.AtName("note"), "Secret note", diags)
+
+		if secret.Key.IsUnknown() || secret.ProjectId.IsUnknown() || secret.OrganizationId.IsUnknown() {
+			continue
+		}
+
+		// Secrets without an organization use the provider one, which is only
+		// known once the provider is configured, so they are compared together.
+		duplicateKey := secret.OrganizationId.ValueString() + "/" + secret.ProjectId.ValueString() + "/" + secret.Key.ValueString()
+		if firstIndex, ok := seen[duplicateKey]; ok {
+			diags.AddAttributeError(
+				secretPath.AtName("key"),
+				"Duplicate secret key",
+				fmt.Sprintf("The key %q is already used by the secret at index %d of the same project. Keys must be unique in a project for `bws run` and exports to work.",
+					secret.Key.ValueString(), firstIndex),
+			)
+			continue
+		}
+		seen[duplicateKey] = secretIndex
+	}
+}
+
+// validateProjectItems checks the projects of a configuration without the
+// API: names must not be blank, must fit the server limit and must not be
+// configured twice for the same organization.
+func validateProjectItems(projects []projectItemModel, diags *diag.Diagnostics) {
+	seen := make(map[string]int)
+	for projectIndex, project := range projects {
+		namePath := path.Root("projects").AtListIndex(projectIndex).AtName("name")
+
+		validateLength(project.Name, true, maxProjectNameLength, namePath, "Project name", diags)
+
+		if project.Name.IsUnknown() || project.OrganizationId.IsUnknown() {
+			continue
+		}
+
+		duplicateName := project.OrganizationId.ValueString() + "/" + project.Name.ValueString()
+		if firstIndex, ok := seen[duplicateName]; ok {
+			diags.AddAttributeError(
+				namePath,
+				"Duplicate project name",
+				fmt.Sprintf("The name %q is already used by the project at index %d of the same organization.", project.Name.ValueString(), firstIndex),
+			)
+			continue
+		}
+		seen[duplicateName] = projectIndex
+	}
+}
+
+// validateLength rejects values longer than the server limit, and blank
+// values when required. label names the value in diagnostics, such as
+// "Secret key".
+func validateLength(value types.String, required bool, maxLength int, attributePath path.Path, label string, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	if required && strings.TrimSpace(value.ValueString()) == "" {
+		diags.AddAttributeError(
+			attributePath,
+			label+" is empty",
+			label+" must contain at least one non-whitespace character.",
+		)
+		return
+	}
+
+	if length := utf8.RuneCountInString(value.ValueString()); length > maxLength {
+		diags.AddAttributeError(
+			attributePath,
+			label+" is too long",
+			fmt.Sprintf("%s is %d characters long, Bitwarden accepts at most %d.", label, length, maxLength),
+		)
+	}
+}
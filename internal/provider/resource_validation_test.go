@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	testValidationProjectId      = "5f4b2a1c-8d3e-4c7a-9b6f-1e2d3c4b5a69"
+	testValidationOtherProjectId = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+func testSecretItem(key string, value string, note string, projectId string) secretItemModel {
+	return secretItemModel{
+		Key:            types.StringValue(key),
+		Value:          types.StringValue(value),
+		Note:           types.StringValue(note),
+		SecretId:       types.StringUnknown(),
+		ProjectId:      types.StringValue(projectId),
+		OrganizationId: types.StringNull(),
+	}
+}
+
+func TestValidateSecretItems(t *testing.T) {
+	testCases := map[string]struct {
+		secrets []secretItemModel
+		summary string
+	}{
+		"valid": {
+			secrets: []secretItemModel{
+				testSecretItem("DB_USER", "admin", "", testValidationProjectId),
+				testSecretItem("DB_PASSWORD", "", "rotated monthly", testValidationProjectId),
+			},
+		},
+		"same key in other projects": {
+			secrets: []secretItemModel{
+				testSecretItem("DB_USER", "admin", "", testValidationProjectId),
+				testSecretItem("DB_USER", "admin", "", testValidationOtherProjectId),
+			},
+		},
+		"empty key": {
+			secrets: []secretItemModel{testSecretItem("", "admin", "", testValidationProjectId)},
+			summary: "Secret key is empty",
+		},
+		"blank key": {
+			secrets: []secretItemModel{testSecretItem("  ", "admin", "", testValidationProjectId)},
+			summary: "Secret key is empty",
+		},
+		"key too long": {
+			secrets: []secretItemModel{testSecretItem(strings.Repeat("K", maxSecretKeyLength+1), "admin", "", testValidationProjectId)},
+			summary: "Secret key is too long",
+		},
+		"value too long": {
+			secrets: []secretItemModel{testSecretItem("DB_USER", strings.Repeat("v", maxSecretValueLength+1), "", testValidationProjectId)},
+			summary: "Secret value is too long",
+		},
+		"note too long": {
+			secrets: []secretItemModel{testSecretItem("DB_USER", "admin", strings.Repeat("é", maxSecretNoteLength+1), testValidationProjectId)},
+			summary: "Secret note is too long",
+		},
+		"duplicate key": {
+			secrets: []secretItemModel{
+				testSecretItem("DB_USER", "admin", "", testValidationProjectId),
+				testSecretItem("DB_USER", "root", "", testValidationProjectId),
+			},
+			summary: "Duplicate secret key",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateSecretItems(testCase.secrets, &diags)
+
+			assertValidationSummary(t, diags, testCase.summary)
+		})
+	}
+}
+
+func TestValidateSecretItemsUnknown(t *testing.T) {
+	unknownKey := testSecretItem("DB_USER", "admin", "", testValidationProjectId)
+	unknownKey.Key = types.StringUnknown()
+	unknownProject := testSecretItem("DB_USER", "admin", "", testValidationProjectId)
+	unknownProject.ProjectId = types.StringUnknown()
+
+	var diags diag.Diagnostics
+	validateSecretItems([]secretItemModel{
+		unknownKey,
+		unknownKey,
+		testSecretItem("DB_USER", "admin", "", testValidationProjectId),
+		unknownProject,
+	}, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unknown keys and projects must not be reported, got %v", diags)
+	}
+}
+
+func TestValidateProjectItems(t *testing.T) {
+	project := func(name string) projectItemModel {
+		return projectItemModel{
+			Name:           types.StringValue(name),
+			ProjectId:      types.StringUnknown(),
+			OrganizationId: types.StringNull(),
+		}
+	}
+
+	testCases := map[string]struct {
+		projects []projectItemModel
+		summary  string
+	}{
+		"valid": {
+			projects: []projectItemModel{project("production"), project("staging")},
+		},
+		"empty name": {
+			projects: []projectItemModel{project("")},
+			summary:  "Project name is empty",
+		},
+		"name too long": {
+			projects: []projectItemModel{project(strings.Repeat("p", maxProjectNameLength+1))},
+			summary:  "Project name is too long",
+		},
+		"duplicate name": {
+			projects: []projectItemModel{project("production"), project("staging"), project("production")},
+			summary:  "Duplicate project name",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateProjectItems(testCase.projects, &diags)
+
+			assertValidationSummary(t, diags, testCase.summary)
+		})
+	}
+}
+
+// assertValidationSummary checks that diags holds exactly one error with
+// summary, or no error when summary is empty.
+func assertValidationSummary(t *testing.T, diags diag.Diagnostics, summary string) {
+	t.Helper()
+
+	if summary == "" {
+		if diags.HasError() {
+			t.Fatalf("expected no error, got %v", diags)
+		}
+		return
+	}
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	if got := diags.Errors()[0].Summary(); got != summary {
+		t.Fatalf("expected %q, got %q", summary, got)
+	}
+}
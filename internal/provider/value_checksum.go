@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// valueSha256 returns the hex encoded SHA-256 checksum of a secret value. With
+// a key, it is an HMAC-SHA256 instead, so short or guessable values can't be
+// recovered from the checksum by hashing candidates.
+func valueSha256(value string, key []byte) string {
+	if len(key) == 0 {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// valueSha256Value returns the checksum of a value which may not be known
+// yet, such as a planned value.
+func valueSha256Value(value types.String, key []byte) types.String {
+	if value.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if value.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(valueSha256(value.ValueString(), key))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValueSha256(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		key      []byte
+		expected string
+	}{
+		"empty": {
+			expected: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		"sha256": {
+			value:    "abc",
+			expected: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		// RFC 4231, test case 2.
+		"hmac": {
+			value:    "what do ya want for nothing?",
+			key:      []byte("Jefe"),
+			expected: "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if checksum := valueSha256(testCase.value, testCase.key); checksum != testCase.expected {
+				t.Fatalf("expected %s, got %s", testCase.expected, checksum)
+			}
+		})
+	}
+}
+
+func TestValueSha256Value(t *testing.T) {
+	if !valueSha256Value(types.StringUnknown(), nil).IsUnknown() {
+		t.Error("expected the checksum of an unknown value to be unknown")
+	}
+	if !valueSha256Value(types.StringNull(), nil).IsNull() {
+		t.Error("expected the checksum of a null value to be null")
+	}
+	if checksum := valueSha256Value(types.StringValue("abc"), nil); checksum.ValueString() != valueSha256("abc", nil) {
+		t.Errorf("unexpected checksum %s", checksum)
+	}
+}
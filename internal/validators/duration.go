@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"time"
+)
+
+var _ validator.String = durationValidator{}
+
+// durationValidator checks that a string is a positive Go duration.
+type durationValidator struct{}
+
+// Duration returns a validator which ensures that any configured string value
+// is a positive duration such as "500ms" or "1m30s".
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as 500ms or 1m30s"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	duration, err := time.ParseDuration(value)
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("the duration must be positive")
+	}
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid duration",
+			fmt.Sprintf("Durations are written as a number and a unit such as 500ms or 1m30s, got %q: %s.", value, err),
+		)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDuration(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"seconds":  {value: types.StringValue("30s")},
+		"compound": {value: types.StringValue("1m30s")},
+		"null":     {value: types.StringNull()},
+		"unknown":  {value: types.StringUnknown()},
+		"no unit":  {value: types.StringValue("30"), expectErr: true},
+		"zero":     {value: types.StringValue("0s"), expectErr: true},
+		"negative": {value: types.StringValue("-1s"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			response := &validator.StringResponse{}
+			Duration().ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("min_backoff"),
+				ConfigValue: testCase.value,
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+		})
+	}
+}
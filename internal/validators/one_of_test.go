@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOneOf(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"allowed": {value: types.StringValue("abandon")},
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+		"other":   {value: types.StringValue("keep"), expectErr: true},
+		"case":    {value: types.StringValue("Delete"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			response := &validator.StringResponse{}
+			OneOf("delete", "abandon").ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("on_destroy"),
+				ConfigValue: testCase.value,
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"regexp"
+)
+
+var _ validator.String = regexpValidator{}
+
+// regexpValidator checks that a string matches a regular expression.
+type regexpValidator struct {
+	pattern     *regexp.Regexp
+	description string
+}
+
+// Regexp returns a validator which ensures that any configured string value
+// matches pattern. description says what the pattern allows, such as
+// "one or more letters, digits or underscores".
+func Regexp(pattern *regexp.Regexp, description string) validator.String {
+	return regexpValidator{pattern: pattern, description: description}
+}
+
+func (v regexpValidator) Description(_ context.Context) string {
+	return "value must be " + v.description
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if !v.pattern.MatchString(value) {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Value",
+			fmt.Sprintf("The %s, got: %q.", v.Description(ctx), value),
+		)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRegexp(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"match":   {value: types.StringValue("a_1")},
+		"null":    {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+		"empty":   {value: types.StringValue(""), expectErr: true},
+		"invalid": {value: types.StringValue("$(id)"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributePath := path.Root("invalid_char_replacement")
+			response := &validator.StringResponse{}
+			Regexp(regexp.MustCompile(`^[A-Za-z0-9_]+$`), "one or more letters, digits or underscores").ValidateString(context.Background(), validator.StringRequest{
+				Path:        attributePath,
+				ConfigValue: testCase.value,
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if testCase.expectErr {
+				diagnostic, ok := response.Diagnostics[0].(interface{ Path() path.Path })
+				if !ok || !diagnostic.Path().Equal(attributePath) {
+					t.Fatalf("expected the diagnostic to point at %s", attributePath)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package validators contains schema validators shared by the provider,
+// its resources and its data sources.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = uuidValidator{}
+
+// uuidValidator checks that a string is a UUID, which is the format of every
+// id used by Bitwarden Secrets Manager.
+type uuidValidator struct{}
+
+// UUID returns a validator which ensures that any configured string value is
+// a UUID such as 00000000-0000-0000-0000-000000000000.
+func UUID() validator.String {
+	return uuidValidator{}
+}
+
+func (v uuidValidator) Description(_ context.Context) string {
+	return "value must be a UUID"
+}
+
+func (v uuidValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uuidValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if _, err := uuid.ParseUUID(value); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid UUID",
+			fmt.Sprintf("Bitwarden ids are UUIDs such as 00000000-0000-0000-0000-000000000000, got: %q.", value),
+		)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUUID(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"uuid":        {value: types.StringValue("a9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60")},
+		"null":        {value: types.StringNull()},
+		"unknown":     {value: types.StringUnknown()},
+		"empty":       {value: types.StringValue(""), expectErr: true},
+		"name":        {value: types.StringValue("my-project"), expectErr: true},
+		"no dashes":   {value: types.StringValue("a9ab6d8e3b8e4d1c9a4f2c8e5f1b7d60"), expectErr: true},
+		"bad charset": {value: types.StringValue("z9ab6d8e-3b8e-4d1c-9a4f-2c8e5f1b7d60"), expectErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributePath := path.Root("secrets").AtListIndex(0).AtName("project_id")
+			response := &validator.StringResponse{}
+			UUID().ValidateString(context.Background(), validator.StringRequest{
+				Path:        attributePath,
+				ConfigValue: testCase.value,
+			}, response)
+
+			if response.Diagnostics.HasError() != testCase.expectErr {
+				t.Fatalf("unexpected diagnostics: %v", response.Diagnostics)
+			}
+			if testCase.expectErr {
+				diagnostic, ok := response.Diagnostics[0].(interface{ Path() path.Path })
+				if !ok || !diagnostic.Path().Equal(attributePath) {
+					t.Fatalf("expected the diagnostic to point at %s", attributePath)
+				}
+			}
+		})
+	}
+}